@@ -0,0 +1,296 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口设备通道拓扑
+ */
+package device
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ChannelRole 通道角色（对应ChannelAttr.Name）
+type ChannelRole string
+
+const (
+	// 通道角色：单目设备
+	ChannelRole_Default ChannelRole = "default"
+	// 通道角色：二郎神细节路
+	ChannelRole_Details ChannelRole = "details"
+	// 通道角色：二郎神全景路
+	ChannelRole_Panorama ChannelRole = "panorama"
+	// 通道角色：魔方细节路
+	ChannelRole_MagicMaster ChannelRole = "magicMaster"
+	// 通道角色：魔方全景路
+	ChannelRole_MagicSlave ChannelRole = "magicSlave"
+	// 通道角色：星图定点
+	ChannelRole_Omni ChannelRole = "omni"
+	// 通道角色：星图动点1
+	ChannelRole_OmniMove1 ChannelRole = "omniMove1"
+	// 通道角色：星图动点2
+	ChannelRole_OmniMove2 ChannelRole = "omniMove2"
+	// 通道角色：1tN本地设备视频路
+	ChannelRole_OneToN ChannelRole = "1tN"
+	// 通道角色：子母机的母机视频路
+	ChannelRole_SlaveMaster ChannelRole = "slaveMaster"
+	// 通道角色：热成像的可见光路
+	ChannelRole_VisibleLight ChannelRole = "visibleLight"
+	// 通道角色：热成像的红外路
+	ChannelRole_ThermalImaging ChannelRole = "thermalImaging"
+)
+
+// 成对的通道角色（细节路 <-> 全景路，可见光路 <-> 红外路）
+var channelRolePairs = map[ChannelRole]ChannelRole{
+	ChannelRole_Details:        ChannelRole_Panorama,
+	ChannelRole_Panorama:       ChannelRole_Details,
+	ChannelRole_MagicMaster:    ChannelRole_MagicSlave,
+	ChannelRole_MagicSlave:     ChannelRole_MagicMaster,
+	ChannelRole_VisibleLight:   ChannelRole_ThermalImaging,
+	ChannelRole_ThermalImaging: ChannelRole_VisibleLight,
+}
+
+// IsPanorama 是否为全景路
+func (r ChannelRole) IsPanorama() bool {
+	return r == ChannelRole_Panorama || r == ChannelRole_MagicSlave
+}
+
+// IsDetail 是否为细节路
+func (r ChannelRole) IsDetail() bool {
+	return r == ChannelRole_Details || r == ChannelRole_MagicMaster
+}
+
+// ChannelChip 通道所在芯片（由通道ID编号规则确定）
+type ChannelChip int
+
+const (
+	// 通道所在芯片：主芯片（101、102……）
+	ChannelChip_Main ChannelChip = iota
+	// 通道所在芯片：从芯片（2001、2002……）
+	ChannelChip_Slave
+	// 通道所在芯片：一拖N从机（10001、10002……）
+	ChannelChip_OneToNSlave
+)
+
+// ChannelChipOf 根据通道ID判断通道所在芯片
+func ChannelChipOf(channelID int) ChannelChip {
+	switch {
+	case channelID >= 10001:
+		return ChannelChip_OneToNSlave
+	case channelID >= 2001:
+		return ChannelChip_Slave
+	default:
+		return ChannelChip_Main
+	}
+}
+
+// Channel 通道
+type Channel struct {
+	UUID        string            // 通道UUID
+	ID          int               // 通道ID（解析失败时为0）
+	Role        ChannelRole       // 通道角色
+	Desc        string            // 设备描述（default、1tN、ods）
+	Remote      bool              // 是否位于远端
+	RemoteIndex int               // 远端序号（从1开始，本地通道为0）
+	ImagingTech []string          // 成像类型（如visible_light、focus、thermal_imaging）
+	Forward     bool              // 是否需要转发到对应通道
+	DeviceID    string            // 设备ID（来自主动注册信息，可能为空）
+	FuncList    map[string]string // 通道功能
+}
+
+// Chip 通道所在芯片
+func (c *Channel) Chip() ChannelChip {
+	return ChannelChipOf(c.ID)
+}
+
+// IsThermal 是否为红外成像通道
+func (c *Channel) IsThermal() bool {
+	if c.Role == ChannelRole_ThermalImaging {
+		return true
+	}
+	for _, tech := range c.ImagingTech {
+		if tech == "thermal_imaging" {
+			return true
+		}
+	}
+	return false
+}
+
+// 解析位置（local、remote;index1）
+func parseChannelLocation(location string) (bool, int) {
+	parts := strings.Split(location, ";")
+	if strings.TrimSpace(parts[0]) != "remote" {
+		return false, 0
+	}
+	index := 1
+	if len(parts) > 1 {
+		if n, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(parts[1]), "index")); err == nil {
+			index = n
+		}
+	}
+	return true, index
+}
+
+// ChannelTopology 设备通道拓扑
+type ChannelTopology struct {
+	Channels []*Channel          // 全部通道（按通道ID排序）
+	byUUID   map[string]*Channel // 按UUID索引
+	byID     map[int]*Channel    // 按通道ID索引
+}
+
+// NewChannelTopology 构建设备通道拓扑
+//
+//	@param info: 设备通道信息（ChannelInfoQuery）
+//	@param base: 主动注册上报的通道基础信息（选填，用于补充设备ID）
+//	@return 设备通道拓扑
+func NewChannelTopology(info *ChannelInfoQueryReply, base []ChannelBaseInfo) *ChannelTopology {
+	deviceIDs := make(map[string]string, len(base))
+	baseIDs := make(map[string]int, len(base))
+	for _, item := range base {
+		deviceIDs[item.UUID] = item.DeviceId
+		baseIDs[item.UUID] = item.ChannelId
+	}
+	t := &ChannelTopology{
+		byUUID: make(map[string]*Channel),
+		byID:   make(map[int]*Channel),
+	}
+	for _, item := range info.CnsChnParam {
+		attr := item.AttrList
+		channel := &Channel{
+			UUID:     item.Uuid,
+			Role:     ChannelRole(attr.Name),
+			Desc:     attr.Desc,
+			Forward:  attr.ForwardNeed == "1",
+			DeviceID: deviceIDs[item.Uuid],
+			FuncList: item.FuncList,
+		}
+		if channel.Role == "" {
+			channel.Role = ChannelRole_Default
+		}
+		if id, err := strconv.Atoi(strings.TrimSpace(attr.ChannelID)); err == nil {
+			channel.ID = id
+		} else {
+			channel.ID = baseIDs[item.Uuid]
+		}
+		channel.Remote, channel.RemoteIndex = parseChannelLocation(attr.Location)
+		for _, tech := range strings.Split(attr.ImagingTech, ",") {
+			if tech = strings.TrimSpace(tech); tech != "" {
+				channel.ImagingTech = append(channel.ImagingTech, tech)
+			}
+		}
+		t.Channels = append(t.Channels, channel)
+		t.byUUID[channel.UUID] = channel
+		if channel.ID != 0 {
+			t.byID[channel.ID] = channel
+		}
+	}
+	sort.SliceStable(t.Channels, func(i, j int) bool { return t.Channels[i].ID < t.Channels[j].ID })
+	return t
+}
+
+// Channel 按UUID获取通道
+func (t *ChannelTopology) Channel(uuid string) (*Channel, bool) {
+	channel, ok := t.byUUID[uuid]
+	return channel, ok
+}
+
+// ChannelByID 按通道ID获取通道
+func (t *ChannelTopology) ChannelByID(id int) (*Channel, bool) {
+	channel, ok := t.byID[id]
+	return channel, ok
+}
+
+// ByRole 获取指定角色的全部通道
+func (t *ChannelTopology) ByRole(role ChannelRole) []*Channel {
+	var channels []*Channel
+	for _, channel := range t.Channels {
+		if channel.Role == role {
+			channels = append(channels, channel)
+		}
+	}
+	return channels
+}
+
+// Counterpart 获取成对通道（细节路对应的全景路、全景路对应的细节路、可见光路对应的红外路等）
+//
+//	优先匹配同一位置（本地或同一远端）的通道
+func (t *ChannelTopology) Counterpart(uuid string) (*Channel, bool) {
+	channel, ok := t.byUUID[uuid]
+	if !ok {
+		return nil, false
+	}
+	role, ok := channelRolePairs[channel.Role]
+	if !ok {
+		return nil, false
+	}
+	var fallback *Channel
+	for _, candidate := range t.Channels {
+		if candidate.Role != role {
+			continue
+		}
+		if candidate.Remote == channel.Remote && candidate.RemoteIndex == channel.RemoteIndex {
+			return candidate, true
+		}
+		if fallback == nil {
+			fallback = candidate
+		}
+	}
+	return fallback, fallback != nil
+}
+
+// Panorama 获取细节路对应的全景路
+func (t *ChannelTopology) Panorama(uuid string) (*Channel, bool) {
+	channel, ok := t.byUUID[uuid]
+	if !ok || !channel.Role.IsDetail() {
+		return nil, false
+	}
+	return t.Counterpart(uuid)
+}
+
+// Detail 获取全景路对应的细节路
+func (t *ChannelTopology) Detail(uuid string) (*Channel, bool) {
+	channel, ok := t.byUUID[uuid]
+	if !ok || !channel.Role.IsPanorama() {
+		return nil, false
+	}
+	return t.Counterpart(uuid)
+}
+
+// ChannelRoute 通道调用路由
+type ChannelRoute struct {
+	UUID      string // 通道UUID
+	ChannelID int    // 通道ID（需要转发时必须携带，否则为0）
+}
+
+// Route 获取通道调用路由
+//
+// 需要转发（ForwardNeed为"1"）或位于远端的通道，按通道调用接口时需携带通道ID，
+// 其他管理器可据此填写参数（如snapshot.SnapActionParams.ChannelID）。
+//
+//	@param uuid: 通道UUID
+//	@return 通道调用路由
+//	@return 异常信息（通道不存在时）
+func (t *ChannelTopology) Route(uuid string) (ChannelRoute, error) {
+	channel, ok := t.byUUID[uuid]
+	if !ok {
+		return ChannelRoute{}, fmt.Errorf("channel not found: %s", uuid)
+	}
+	route := ChannelRoute{UUID: channel.UUID}
+	if channel.Forward || channel.Remote {
+		route.ChannelID = channel.ID
+	}
+	return route, nil
+}
+
+// ChannelTopologyQuery 设备通道拓扑查询
+//
+//	@param	base: 主动注册上报的通道基础信息（选填，用于补充设备ID）
+func (p *Manager) ChannelTopologyQuery(base ...ChannelBaseInfo) (*ChannelTopology, error) {
+	info, err := p.ChannelInfoQuery()
+	if err != nil {
+		return nil, err
+	}
+	return NewChannelTopology(info, base), nil
+}
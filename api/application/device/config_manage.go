@@ -0,0 +1,133 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口设备配置文件管理
+ */
+package device
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
+	"github.com/kaicen-x/holosens-sdc-sdk/pkg/httpconn"
+)
+
+var (
+	// ErrNoConfigContent：导出响应中没有配置文件内容
+	ErrNoConfigContent = errors.New("no config file content")
+)
+
+// 下载文件到输出（设备以JSON返回错误信息）
+func download(req *httpconn.HttpClientRequest, client *httpconn.HttpClient, w io.Writer) (int64, error) {
+	// 发送请求
+	res, err := req.Send()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		// 读完剩余数据，避免残留数据影响同一连接上的后续请求
+		io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+	}()
+
+	// 检查响应状态码
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+		if len(body) > 0 {
+			return 0, errors.New(string(body))
+		}
+		return 0, errors.New(res.Status)
+	}
+	if strings.Contains(res.Header.Get("Content-Type"), "json") {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+		var status common.Response[common.ResponseStatus]
+		if json.Unmarshal(body, &status) == nil && status.ResponseStatus.StatusCode != 0 {
+			return 0, errors.New(status.ResponseStatus.StatusString)
+		}
+		return 0, nil
+	}
+
+	// 写入数据
+	return io.Copy(w, client.DeadlineReader(res.Body))
+}
+
+// ConfigExport 导出设备配置文件
+//
+// 配置文件为设备加密格式，仅可导入同款型设备。
+//
+//	@param	w: 配置文件输出
+//	@return	配置文件大小
+//	@return	异常信息
+func (p *Manager) ConfigExport(w io.Writer) (int64, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureConfigFile); err != nil {
+		return 0, err
+	}
+
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 下载配置文件
+	n, err := download(client.Get("/SDCAPI/V1.0/System/ConfigFile").
+		SetContentType("application/x-www-form-urlencoded"), client, w)
+	if err != nil {
+		return n, err
+	}
+	if n == 0 {
+		return 0, ErrNoConfigContent
+	}
+
+	// OK
+	return n, nil
+}
+
+// ConfigImportReply 导入设备配置文件响应
+type ConfigImportReply = common.Response[common.ResponseStatus]
+
+// ConfigImport 导入设备配置文件
+//
+// 导入成功后设备自动重启，可使用根包的MaintainHandle跟踪设备恢复。
+//
+//	@param	r: 配置文件内容
+//	@param	size: 配置文件大小（单位：字节）
+func (p *Manager) ConfigImport(r io.Reader, size int64) error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureConfigFile); err != nil {
+		return err
+	}
+
+	if size <= 0 {
+		return errors.New("config file size required")
+	}
+
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	body := &uploadBodyReader{
+		r:      io.LimitReader(r, size),
+		client: client,
+		total:  size,
+	}
+	var reply ConfigImportReply
+	_, err := client.Post("/SDCAPI/V1.0/System/ConfigFile").
+		SetBody(body, size).
+		SetContentType("application/octet-stream").
+		DecodeJSON(&reply)
+	if err != nil {
+		return err
+	}
+
+	// 检查状态码
+	if reply.ResponseStatus.StatusCode != 0 {
+		return errors.New(reply.ResponseStatus.StatusString)
+	}
+
+	// OK
+	return nil
+}
@@ -6,12 +6,14 @@
 package device
 
 import (
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
 	"github.com/kaicen-x/holosens-sdc-sdk/pkg/httpconn"
 )
 
 // Manager 设备管理与维护管理器
 type Manager struct {
-	connInstance *httpconn.Connect // Socket连接实例
+	connInstance *httpconn.Connect    // Socket连接实例
+	capabilities *common.Capabilities // 设备能力（为nil时不检查）
 }
 
 // NewManager 创建设备管理与维护管理器
@@ -20,3 +22,8 @@ func NewManager(connInstance *httpconn.Connect) *Manager {
 		connInstance: connInstance,
 	}
 }
+
+// SetCapabilities 设置设备能力（调用接口前检查，已确认不支持时返回common.ErrUnsupported）
+func (p *Manager) SetCapabilities(capabilities *common.Capabilities) {
+	p.capabilities = capabilities
+}
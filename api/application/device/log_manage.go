@@ -0,0 +1,203 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口设备日志与诊断信息管理
+ */
+package device
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
+	"github.com/kaicen-x/holosens-sdc-sdk/pkg/httpconn"
+)
+
+var (
+	// ErrNoLogContent：导出响应中没有日志内容
+	ErrNoLogContent = errors.New("no log content")
+	// ErrNoDiagnosisContent：导出响应中没有诊断信息包内容
+	ErrNoDiagnosisContent = errors.New("no diagnosis content")
+)
+
+// DiagnosisReadTimeout 诊断信息包导出的读超时时间（设备现场生成诊断信息包期间不返回响应）
+var DiagnosisReadTimeout = time.Minute * 5
+
+// LogType 日志类型
+type LogType int
+
+const (
+	// 日志类型：操作日志
+	LogType_Operation LogType = 0
+	// 日志类型：安全日志
+	LogType_Security LogType = 1
+	// 日志类型：运行日志
+	LogType_Running LogType = 2
+)
+
+// LogFilter 日志过滤条件
+//
+//	部分固件不支持按时间过滤，此时时间条件将被设备忽略
+type LogFilter struct {
+	// 日志类型（必填）
+	Type LogType
+	// 开始时间（UTC时间戳，单位秒，选填）
+	BeginTime int64
+	// 结束时间（UTC时间戳，单位秒，选填）
+	EndTime int64
+}
+
+// 设置日志过滤条件
+func (p *LogFilter) apply(req *httpconn.HttpClientRequest) error {
+	switch p.Type {
+	case LogType_Operation, LogType_Security, LogType_Running:
+	default:
+		return fmt.Errorf("invalid log type: %d", p.Type)
+	}
+	if p.BeginTime > 0 && p.EndTime > 0 && p.BeginTime > p.EndTime {
+		return errors.New("log begin time after end time")
+	}
+	req.SetQuery("type", strconv.Itoa(int(p.Type)))
+	if p.BeginTime > 0 {
+		req.SetQuery("beginTime", strconv.FormatInt(p.BeginTime, 10))
+	}
+	if p.EndTime > 0 {
+		req.SetQuery("endTime", strconv.FormatInt(p.EndTime, 10))
+	}
+	return nil
+}
+
+// LogInfo 日志信息
+type LogInfo struct {
+	Time     int64   `json:"time"`     // 日志时间（UTC时间戳，单位秒）
+	Type     LogType `json:"type"`     // 日志类型
+	Level    string  `json:"level"`    // 日志级别
+	UserName string  `json:"userName"` // 操作用户（操作与安全日志有效）
+	ClientIP string  `json:"clientIP"` // 客户端地址（操作与安全日志有效）
+	Content  string  `json:"content"`  // 日志内容
+}
+
+// LogQueryReply 日志查询响应
+type LogQueryReply struct {
+	TotalNum   int       `json:"totalNum"`   // 符合条件的日志总数
+	BeginIndex int       `json:"beginIndex"` // 本次返回的开始序号
+	EndIndex   int       `json:"endIndex"`   // 本次返回的结束序号
+	Logs       []LogInfo `json:"logs"`       // 日志列表
+}
+
+// LogQuery 日志查询
+//
+//	@param	filter: 日志过滤条件
+//	@param	beginIndex: 开始序号（从1开始）
+//	@param	endIndex: 结束序号（单次最多100条）
+func (p *Manager) LogQuery(filter LogFilter, beginIndex, endIndex int) (*LogQueryReply, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureLog); err != nil {
+		return nil, err
+	}
+
+	if beginIndex < 1 || endIndex < beginIndex || endIndex-beginIndex >= 100 {
+		return nil, fmt.Errorf("invalid log index range: %d-%d", beginIndex, endIndex)
+	}
+
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 构建请求
+	req := client.Get("/SDCAPI/V1.0/System/Log").
+		SetContentType("application/x-www-form-urlencoded").
+		SetQuery("beginIndex", strconv.Itoa(beginIndex)).
+		SetQuery("endIndex", strconv.Itoa(endIndex))
+	if err := filter.apply(req); err != nil {
+		return nil, err
+	}
+
+	// 发送请求
+	var reply LogQueryReply
+	if _, err := req.DecodeJSON(&reply); err != nil {
+		return nil, err
+	}
+
+	// OK
+	return &reply, nil
+}
+
+// LogDownload 日志导出
+//
+// 日志文件以流的方式写入输出，不会整体缓存在内存中。
+//
+//	@param	filter: 日志过滤条件
+//	@param	w: 日志文件输出
+//	@return	日志文件大小
+//	@return	异常信息
+func (p *Manager) LogDownload(filter LogFilter, w io.Writer) (int64, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureLog); err != nil {
+		return 0, err
+	}
+
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 构建请求
+	req := client.Get("/SDCAPI/V1.0/System/Log/Export").
+		SetContentType("application/x-www-form-urlencoded")
+	if err := filter.apply(req); err != nil {
+		return 0, err
+	}
+
+	// 下载日志文件
+	n, err := download(req, client, w)
+	if err != nil {
+		return n, err
+	}
+	if n == 0 {
+		return 0, ErrNoLogContent
+	}
+
+	// OK
+	return n, nil
+}
+
+// DiagnosisExport 诊断信息包导出
+//
+// 诊断信息包由设备现场生成，耗时可能较长，导出期间读超时时间放宽为DiagnosisReadTimeout；以流的方式写入输出。
+//
+//	@param	w: 诊断信息包输出
+//	@return	诊断信息包大小
+//	@return	异常信息
+func (p *Manager) DiagnosisExport(w io.Writer) (int64, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureDiagnosis); err != nil {
+		return 0, err
+	}
+
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 放宽读超时时间（等待响应头与读取响应体期间均生效），结束后恢复
+	readTimeout, writeTimeout := client.GetTimeout()
+	if DiagnosisReadTimeout > readTimeout {
+		client.SetTimeout(DiagnosisReadTimeout, writeTimeout)
+		defer client.SetTimeout(readTimeout, writeTimeout)
+	}
+
+	// 下载诊断信息包
+	n, err := download(client.Get("/SDCAPI/V1.0/System/Diagnosis/Export").
+		SetContentType("application/x-www-form-urlencoded"), client, w)
+	if err != nil {
+		return n, err
+	}
+	if n == 0 {
+		return 0, ErrNoDiagnosisContent
+	}
+
+	// OK
+	return n, nil
+}
@@ -0,0 +1,108 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口设备维护管理
+ */
+package device
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
+)
+
+// MaintainReply 设备维护操作响应
+type MaintainReply = common.Response[common.ResponseStatus]
+
+// 发送设备维护操作请求
+func (p *Manager) maintain(path string, params any) error {
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply MaintainReply
+	req := client.Put(path)
+	if params != nil {
+		req = req.SetJSON(params)
+	}
+	_, err := req.DecodeJSON(&reply)
+	if err != nil {
+		return err
+	}
+
+	// 检查状态码
+	if reply.ResponseStatus.StatusCode != 0 {
+		return errors.New(reply.ResponseStatus.StatusString)
+	}
+
+	// OK
+	return nil
+}
+
+// Reboot 设备重启
+//
+// 设备响应后开始重启，当前连接随即断开。
+func (p *Manager) Reboot() error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureMaintain); err != nil {
+		return err
+	}
+
+	return p.maintain("/SDCAPI/V1.0/System/Reboot", nil)
+}
+
+// RestoreDefaultParams 恢复默认配置参数
+type RestoreDefaultParams struct {
+	// 是否保留网络配置（保留时设备重启后仍可通过原地址访问并重新注册）
+	KeepNetwork bool `json:"keepNetwork"`
+	// 是否保留用户配置
+	KeepUser bool `json:"keepUser"`
+}
+
+// RestoreDefault 恢复默认配置
+//
+// 设备恢复默认配置后自动重启；不保留网络配置时设备将使用出厂IP地址且不再主动注册。
+//
+//	@param	params: 恢复默认配置参数
+func (p *Manager) RestoreDefault(params RestoreDefaultParams) error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureMaintain); err != nil {
+		return err
+	}
+
+	return p.maintain("/SDCAPI/V1.0/System/RestoreDefault", &params)
+}
+
+// ClearTarget 数据清除目标
+type ClearTarget string
+
+const (
+	// 数据清除目标：存储卡（格式化，清除录像与抓拍图片）
+	ClearTarget_Storage ClearTarget = "storage"
+	// 数据清除目标：抓拍图片缓存
+	ClearTarget_Snapshot ClearTarget = "snapshot"
+)
+
+// ClearParams 数据清除参数
+type ClearParams struct {
+	Target ClearTarget `json:"target"` // 清除目标
+}
+
+// Clear 清除设备数据
+//
+//	@param	target: 清除目标
+func (p *Manager) Clear(target ClearTarget) error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureMaintain); err != nil {
+		return err
+	}
+
+	switch target {
+	case ClearTarget_Storage, ClearTarget_Snapshot:
+	default:
+		return fmt.Errorf("invalid clear target: %s", target)
+	}
+	return p.maintain("/SDCAPI/V1.0/Storage/Clear", &ClearParams{Target: target})
+}
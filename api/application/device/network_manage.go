@@ -0,0 +1,308 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口设备网络配置管理
+ */
+package device
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
+)
+
+// NetworkInterface 网口配置
+type NetworkInterface struct {
+	// 网口名称（如：eth0）
+	Name string `json:"name"`
+	// MAC地址（只读）
+	Mac string `json:"mac,omitempty"`
+	// 是否启用DHCP
+	DhcpEnable bool `json:"dhcpEnable"`
+	// IPv4地址
+	IpAddr string `json:"ipAddr"`
+	// 子网掩码
+	Netmask string `json:"netmask"`
+	// 默认网关
+	Gateway string `json:"gateway"`
+	// IPv6地址（选填）
+	Ipv6Addr string `json:"ipv6Addr,omitempty"`
+	// IPv6前缀长度（选填）
+	Ipv6Prefix int `json:"ipv6Prefix,omitempty"`
+	// MTU
+	//
+	//	取值范围：[576,1500]，0表示不修改
+	Mtu int `json:"mtu,omitempty"`
+	// 是否启用VLAN
+	VlanEnable bool `json:"vlanEnable"`
+	// VLAN ID
+	//
+	//	取值范围：[1,4094]
+	VlanId int `json:"vlanId,omitempty"`
+}
+
+// Validate 校验网口配置
+func (p *NetworkInterface) Validate() error {
+	if p.Name == "" {
+		return errors.New("interface name required")
+	}
+	if !p.DhcpEnable {
+		ip := net.ParseIP(p.IpAddr).To4()
+		if ip == nil {
+			return fmt.Errorf("invalid ip address: %s", p.IpAddr)
+		}
+		mask := net.ParseIP(p.Netmask).To4()
+		if mask == nil {
+			return fmt.Errorf("invalid netmask: %s", p.Netmask)
+		}
+		if ones, bits := net.IPMask(mask).Size(); ones == 0 && bits == 0 {
+			return fmt.Errorf("invalid netmask: %s", p.Netmask)
+		}
+		if p.Gateway != "" {
+			gateway := net.ParseIP(p.Gateway).To4()
+			if gateway == nil {
+				return fmt.Errorf("invalid gateway: %s", p.Gateway)
+			}
+			if !ip.Mask(net.IPMask(mask)).Equal(gateway.Mask(net.IPMask(mask))) {
+				return fmt.Errorf("gateway %s not in subnet %s/%s", p.Gateway, p.IpAddr, p.Netmask)
+			}
+		}
+	}
+	if p.Ipv6Addr != "" && net.ParseIP(p.Ipv6Addr) == nil {
+		return fmt.Errorf("invalid ipv6 address: %s", p.Ipv6Addr)
+	}
+	if p.Mtu != 0 && (p.Mtu < 576 || p.Mtu > 1500) {
+		return fmt.Errorf("invalid mtu: %d", p.Mtu)
+	}
+	if p.VlanEnable && (p.VlanId < 1 || p.VlanId > 4094) {
+		return fmt.Errorf("invalid vlan id: %d", p.VlanId)
+	}
+	return nil
+}
+
+// NetworkInterfaceQueryReply 网口配置查询响应
+type NetworkInterfaceQueryReply struct {
+	Interfaces []NetworkInterface `json:"interfaces"` // 网口配置列表
+}
+
+// NetworkInterfaceQuery 网口配置查询
+func (p *Manager) NetworkInterfaceQuery() (*NetworkInterfaceQueryReply, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureNetwork); err != nil {
+		return nil, err
+	}
+
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply NetworkInterfaceQueryReply
+	_, err := client.Get("/SDCAPI/V1.0/Network/Interface").
+		SetContentType("application/x-www-form-urlencoded").
+		DecodeJSON(&reply)
+	if err != nil {
+		return nil, err
+	}
+
+	// OK
+	return &reply, nil
+}
+
+// NetworkInterfaceSettingReply 网口配置响应
+type NetworkInterfaceSettingReply = common.Response[common.ResponseStatus]
+
+// NetworkInterfaceSetting 网口配置
+//
+// 修改IP地址后当前连接通常会断开，请使用根包的SafeApplyNetwork确认设备恢复连接。
+//
+//	@param	params: 网口配置
+func (p *Manager) NetworkInterfaceSetting(params NetworkInterface) error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureNetwork); err != nil {
+		return err
+	}
+
+	// 校验参数
+	if err := params.Validate(); err != nil {
+		return err
+	}
+	params.Mac = ""
+
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply NetworkInterfaceSettingReply
+	_, err := client.Put("/SDCAPI/V1.0/Network/Interface").
+		SetQuery("name", params.Name).
+		SetJSON(&params).
+		DecodeJSON(&reply)
+	if err != nil {
+		return err
+	}
+
+	// 检查状态码
+	if reply.ResponseStatus.StatusCode != 0 {
+		return errors.New(reply.ResponseStatus.StatusString)
+	}
+
+	// OK
+	return nil
+}
+
+// DnsInfo DNS配置
+type DnsInfo struct {
+	PreferredDns string `json:"preferredDns"` // 首选DNS服务器
+	AlternateDns string `json:"alternateDns"` // 备用DNS服务器
+}
+
+// DnsQuery DNS配置查询
+func (p *Manager) DnsQuery() (*DnsInfo, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureNetwork); err != nil {
+		return nil, err
+	}
+
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply DnsInfo
+	_, err := client.Get("/SDCAPI/V1.0/Network/DNS").
+		SetContentType("application/x-www-form-urlencoded").
+		DecodeJSON(&reply)
+	if err != nil {
+		return nil, err
+	}
+
+	// OK
+	return &reply, nil
+}
+
+// DnsSettingReply DNS配置响应
+type DnsSettingReply = common.Response[common.ResponseStatus]
+
+// DnsSetting DNS配置
+//
+//	@param	params: DNS配置
+func (p *Manager) DnsSetting(params DnsInfo) error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureNetwork); err != nil {
+		return err
+	}
+
+	// 校验参数
+	for _, addr := range []string{params.PreferredDns, params.AlternateDns} {
+		if addr != "" && net.ParseIP(addr) == nil {
+			return fmt.Errorf("invalid dns server: %s", addr)
+		}
+	}
+
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply DnsSettingReply
+	_, err := client.Put("/SDCAPI/V1.0/Network/DNS").
+		SetJSON(&params).
+		DecodeJSON(&reply)
+	if err != nil {
+		return err
+	}
+
+	// 检查状态码
+	if reply.ResponseStatus.StatusCode != 0 {
+		return errors.New(reply.ResponseStatus.StatusString)
+	}
+
+	// OK
+	return nil
+}
+
+// RegisterPlatformInfo 主动注册平台配置
+type RegisterPlatformInfo struct {
+	// 是否启用主动注册
+	Enable bool `json:"enable"`
+	// 平台地址（IP或域名）
+	Address string `json:"address"`
+	// 平台端口
+	Port int `json:"port"`
+	// 是否使用TLS连接
+	TlsEnable bool `json:"tlsEnable"`
+}
+
+// RegisterPlatformQuery 主动注册平台配置查询
+func (p *Manager) RegisterPlatformQuery() (*RegisterPlatformInfo, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureNetwork); err != nil {
+		return nil, err
+	}
+
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply RegisterPlatformInfo
+	_, err := client.Get("/SDCAPI/V1.0/Network/InitiativeRegister").
+		SetContentType("application/x-www-form-urlencoded").
+		DecodeJSON(&reply)
+	if err != nil {
+		return nil, err
+	}
+
+	// OK
+	return &reply, nil
+}
+
+// RegisterPlatformSettingReply 主动注册平台配置响应
+type RegisterPlatformSettingReply = common.Response[common.ResponseStatus]
+
+// RegisterPlatformSetting 主动注册平台配置
+//
+// 修改平台地址后设备会断开当前连接并向新地址注册，请使用根包的SafeApplyNetwork确认设备重新注册。
+//
+//	@param	params: 主动注册平台配置
+func (p *Manager) RegisterPlatformSetting(params RegisterPlatformInfo) error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureNetwork); err != nil {
+		return err
+	}
+
+	// 校验参数
+	if params.Enable {
+		if params.Address == "" {
+			return errors.New("platform address required")
+		}
+		if params.Port <= 0 || params.Port > 65535 {
+			return fmt.Errorf("invalid platform port: %d", params.Port)
+		}
+	}
+
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply RegisterPlatformSettingReply
+	_, err := client.Put("/SDCAPI/V1.0/Network/InitiativeRegister").
+		SetJSON(&params).
+		DecodeJSON(&reply)
+	if err != nil {
+		return err
+	}
+
+	// 检查状态码
+	if reply.ResponseStatus.StatusCode != 0 {
+		return errors.New(reply.ResponseStatus.StatusString)
+	}
+
+	// OK
+	return nil
+}
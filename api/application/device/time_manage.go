@@ -0,0 +1,397 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口设备时间管理
+ */
+package device
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
+)
+
+// 设备时间格式
+const systemTimeLayout = "2006-01-02 15:04:05"
+
+// SystemTimeInfo 系统时间信息
+type SystemTimeInfo struct {
+	// UTC时间（格式：YYYY-MM-DD hh:mm:ss）
+	UTCTime string `json:"UTCTime"`
+	// 设备本地时间（格式：YYYY-MM-DD hh:mm:ss，仅查询时返回）
+	LocalTime string `json:"localTime,omitempty"`
+}
+
+// UTC 解析UTC时间
+func (p *SystemTimeInfo) UTC() (time.Time, error) {
+	return time.ParseInLocation(systemTimeLayout, p.UTCTime, time.UTC)
+}
+
+// SystemTimeQuery 系统时间查询
+func (p *Manager) SystemTimeQuery() (*SystemTimeInfo, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureTime); err != nil {
+		return nil, err
+	}
+
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply SystemTimeInfo
+	_, err := client.Get("/SDCAPI/V1.0/System/Time").
+		SetContentType("application/x-www-form-urlencoded").
+		DecodeJSON(&reply)
+	if err != nil {
+		return nil, err
+	}
+
+	// OK
+	return &reply, nil
+}
+
+// SystemTimeSettingReply 系统时间配置响应
+type SystemTimeSettingReply = common.Response[common.ResponseStatus]
+
+// SystemTimeSetting 系统时间配置
+//
+//	@param	utc: 设置的时间（按UTC时间下发）
+func (p *Manager) SystemTimeSetting(utc time.Time) error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureTime); err != nil {
+		return err
+	}
+
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply SystemTimeSettingReply
+	_, err := client.Put("/SDCAPI/V1.0/System/Time").
+		SetJSON(&SystemTimeInfo{UTCTime: utc.UTC().Format(systemTimeLayout)}).
+		DecodeJSON(&reply)
+	if err != nil {
+		return err
+	}
+
+	// 检查状态码
+	if reply.ResponseStatus.StatusCode != 0 {
+		return errors.New(reply.ResponseStatus.StatusString)
+	}
+
+	// OK
+	return nil
+}
+
+// DstRule 夏令时规则
+type DstRule struct {
+	Month   int    `json:"month"`   // 月（1~12）
+	Week    int    `json:"week"`    // 第几周（1~5，5表示最后一周）
+	WeekDay int    `json:"weekDay"` // 星期（0-星期日，1~6-星期一至星期六）
+	Time    string `json:"time"`    // 时间（格式：hh:mm:ss）
+}
+
+// TimeZoneInfo 时区信息
+type TimeZoneInfo struct {
+	// 时区（如：GMT+08:00）
+	TimeZone string `json:"timeZone"`
+	// 是否启用夏令时
+	DstEnable bool `json:"dstEnable"`
+	// 夏令时偏移（单位：分钟）
+	DstOffset int `json:"dstOffset"`
+	// 夏令时开始规则
+	DstStart DstRule `json:"dstStart"`
+	// 夏令时结束规则
+	DstEnd DstRule `json:"dstEnd"`
+}
+
+// Offset 解析时区偏移（不含夏令时）
+func (p *TimeZoneInfo) Offset() (time.Duration, error) {
+	var sign byte
+	var hour, minute int
+	if _, err := fmt.Sscanf(p.TimeZone, "GMT%c%d:%d", &sign, &hour, &minute); err != nil {
+		if p.TimeZone == "GMT" || p.TimeZone == "UTC" {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("invalid time zone: %s", p.TimeZone)
+	}
+	offset := time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute
+	if sign == '-' {
+		offset = -offset
+	}
+	return offset, nil
+}
+
+// OffsetAt 解析指定本地时间的时区偏移（含夏令时）
+//
+//	@param	local: 摄像机本地时间（以UTC表示的墙上时间）
+func (p *TimeZoneInfo) OffsetAt(local time.Time) (time.Duration, error) {
+	offset, err := p.Offset()
+	if err != nil || !p.DstEnable {
+		return offset, err
+	}
+	start, err := p.DstStart.at(local.Year())
+	if err != nil {
+		return 0, err
+	}
+	end, err := p.DstEnd.at(local.Year())
+	if err != nil {
+		return 0, err
+	}
+	// 南半球夏令时跨年（开始时间晚于结束时间）
+	var inDst bool
+	if start.Before(end) {
+		inDst = !local.Before(start) && local.Before(end)
+	} else {
+		inDst = !local.Before(start) || local.Before(end)
+	}
+	if inDst {
+		offset += time.Duration(p.DstOffset) * time.Minute
+	}
+	return offset, nil
+}
+
+// 计算指定年份的夏令时切换时间（以UTC表示的墙上时间）
+func (r *DstRule) at(year int) (time.Time, error) {
+	if r.Month < 1 || r.Month > 12 || r.Week < 1 || r.Week > 5 || r.WeekDay < 0 || r.WeekDay > 6 {
+		return time.Time{}, fmt.Errorf("invalid dst rule: %+v", *r)
+	}
+	var hour, minute, second int
+	if _, err := fmt.Sscanf(r.Time, "%d:%d:%d", &hour, &minute, &second); err != nil {
+		return time.Time{}, fmt.Errorf("invalid dst rule time: %s", r.Time)
+	}
+	month := time.Month(r.Month)
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	day := 1 + (r.WeekDay-int(first.Weekday())+7)%7 + (r.Week-1)*7
+	// 第5周表示最后一周
+	for day > time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day() {
+		day -= 7
+	}
+	return time.Date(year, month, day, hour, minute, second, 0, time.UTC), nil
+}
+
+// TimeZoneQuery 时区与夏令时查询
+func (p *Manager) TimeZoneQuery() (*TimeZoneInfo, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureTime); err != nil {
+		return nil, err
+	}
+
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply TimeZoneInfo
+	_, err := client.Get("/SDCAPI/V1.0/System/TimeZone").
+		SetContentType("application/x-www-form-urlencoded").
+		DecodeJSON(&reply)
+	if err != nil {
+		return nil, err
+	}
+
+	// OK
+	return &reply, nil
+}
+
+// TimeZoneSettingReply 时区与夏令时配置响应
+type TimeZoneSettingReply = common.Response[common.ResponseStatus]
+
+// TimeZoneSetting 时区与夏令时配置
+//
+//	@param	params: 时区信息
+func (p *Manager) TimeZoneSetting(params TimeZoneInfo) error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureTime); err != nil {
+		return err
+	}
+
+	// 校验时区格式
+	if _, err := params.Offset(); err != nil {
+		return err
+	}
+
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply TimeZoneSettingReply
+	_, err := client.Put("/SDCAPI/V1.0/System/TimeZone").
+		SetJSON(&params).
+		DecodeJSON(&reply)
+	if err != nil {
+		return err
+	}
+
+	// 检查状态码
+	if reply.ResponseStatus.StatusCode != 0 {
+		return errors.New(reply.ResponseStatus.StatusString)
+	}
+
+	// OK
+	return nil
+}
+
+// NtpServer NTP服务器
+type NtpServer struct {
+	Address string `json:"address"` // 服务器地址（IP或域名）
+	Port    int    `json:"port"`    // 服务器端口（默认123）
+}
+
+// NtpInfo NTP配置
+type NtpInfo struct {
+	// 是否启用NTP校时
+	Enable bool `json:"enable"`
+	// NTP服务器列表
+	Servers []NtpServer `json:"servers"`
+	// 校时间隔（单位：分钟）
+	Interval int `json:"interval"`
+}
+
+// NtpQuery NTP配置查询
+func (p *Manager) NtpQuery() (*NtpInfo, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureTime); err != nil {
+		return nil, err
+	}
+
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply NtpInfo
+	_, err := client.Get("/SDCAPI/V1.0/System/NTP").
+		SetContentType("application/x-www-form-urlencoded").
+		DecodeJSON(&reply)
+	if err != nil {
+		return nil, err
+	}
+
+	// OK
+	return &reply, nil
+}
+
+// NtpSettingReply NTP配置响应
+type NtpSettingReply = common.Response[common.ResponseStatus]
+
+// NtpSetting NTP配置
+//
+//	@param	params: NTP配置
+func (p *Manager) NtpSetting(params NtpInfo) error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureTime); err != nil {
+		return err
+	}
+
+	// 校验参数
+	if params.Enable && len(params.Servers) == 0 {
+		return errors.New("ntp servers required")
+	}
+	for i := range params.Servers {
+		if params.Servers[i].Port == 0 {
+			params.Servers[i].Port = 123
+		}
+	}
+
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply NtpSettingReply
+	_, err := client.Put("/SDCAPI/V1.0/System/NTP").
+		SetJSON(&params).
+		DecodeJSON(&reply)
+	if err != nil {
+		return err
+	}
+
+	// 检查状态码
+	if reply.ResponseStatus.StatusCode != 0 {
+		return errors.New(reply.ResponseStatus.StatusString)
+	}
+
+	// OK
+	return nil
+}
+
+// ClockSkewReply 时钟偏差测量结果
+type ClockSkewReply struct {
+	DeviceTime time.Time     // 设备时间（来自响应头Date，精度为秒）
+	Skew       time.Duration // 时钟偏差（设备时间 - 本机时间，正数表示设备时间较快）
+	RoundTrip  time.Duration // 请求往返耗时
+}
+
+// ClockSkew 测量设备与本机的时钟偏差
+//
+//	使用响应头Date计算，受Date精度影响误差约为±1秒
+func (p *Manager) ClockSkew() (*ClockSkewReply, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureTime); err != nil {
+		return nil, err
+	}
+
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply SystemTimeInfo
+	begin := time.Now()
+	res, err := client.Get("/SDCAPI/V1.0/System/Time").
+		SetContentType("application/x-www-form-urlencoded").
+		DecodeJSON(&reply)
+	end := time.Now()
+	if res == nil {
+		return nil, err
+	}
+
+	// 解析响应头Date（任意响应均可，失败时使用响应体中的UTC时间）
+	deviceTime, dateErr := http.ParseTime(res.Header.Get("Date"))
+	if dateErr != nil {
+		if err != nil {
+			return nil, err
+		}
+		if deviceTime, err = reply.UTC(); err != nil {
+			return nil, errors.New("device time unavailable")
+		}
+	}
+	rtt := end.Sub(begin)
+	// Date精度为秒，取该秒的中点以减小误差
+	deviceTime = deviceTime.Add(time.Millisecond * 500)
+	return &ClockSkewReply{
+		DeviceTime: deviceTime,
+		Skew:       deviceTime.Sub(begin.Add(rtt / 2)),
+		RoundTrip:  rtt,
+	}, nil
+}
+
+// CorrectClock 测量时钟偏差，偏差超过阈值时将设备时间设置为本机时间
+//
+//	@param	threshold: 允许的最大偏差（小于等于0时默认为2秒）
+//	@return	校正前的时钟偏差测量结果
+//	@return	是否执行了校正
+//	@return	异常信息
+func (p *Manager) CorrectClock(threshold time.Duration) (*ClockSkewReply, bool, error) {
+	if threshold <= 0 {
+		threshold = time.Second * 2
+	}
+	skew, err := p.ClockSkew()
+	if err != nil {
+		return nil, false, err
+	}
+	if skew.Skew.Abs() <= threshold {
+		return skew, false, nil
+	}
+	if err := p.SystemTimeSetting(time.Now()); err != nil {
+		return skew, false, err
+	}
+	return skew, true, nil
+}
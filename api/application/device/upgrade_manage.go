@@ -0,0 +1,287 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口设备升级管理
+ */
+package device
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
+	"github.com/kaicen-x/holosens-sdc-sdk/pkg/httpconn"
+)
+
+var (
+	// ErrUpgradePackageTooLarge：升级包超出设备限制大小
+	ErrUpgradePackageTooLarge = errors.New("upgrade package too large")
+	// ErrUpgradeDevTypeMismatch：升级包适用款型与设备款型不一致
+	ErrUpgradeDevTypeMismatch = errors.New("upgrade package device type mismatch")
+	// ErrUpgradeFailed：设备升级失败
+	ErrUpgradeFailed = errors.New("upgrade failed")
+)
+
+// 升级成功或重启中状态下连接仍未断开时的最长等待时长
+const upgradeRebootWait = time.Minute * 5
+
+// 升级包上传后连续查询到空闲状态（进度未达100）的次数上限，超出视为升级未开始
+const upgradeIdleMaxCount = 3
+
+// UpgradeParams 升级参数
+type UpgradeParams struct {
+	// 升级包内容（以流的方式发送，不会整体读入内存）
+	Package io.Reader
+	// 升级包大小（单位：字节，必填）
+	Size int64
+	// 升级包适用款型（选填，填写时与设备款型DevType或FullDeviceType比对）
+	DevType string
+	// 上传进度回调（选填）
+	OnUpload func(sent, total int64)
+	// 设备基础信息中升级包限制大小（PackageLimitSize）的单位（单位：字节，选填，默认1048576即MB）
+	//
+	//	接口文档未说明该字段的单位，如设备固件的单位不同请按实际情况填写
+	PackageLimitSizeUnit int64
+}
+
+// UpgradeStatusCode 升级状态
+type UpgradeStatusCode int
+
+const (
+	// 升级状态：空闲
+	UpgradeStatus_Idle UpgradeStatusCode = 0
+	// 升级状态：升级中
+	UpgradeStatus_Upgrading UpgradeStatusCode = 1
+	// 升级状态：升级成功（即将重启）
+	UpgradeStatus_Success UpgradeStatusCode = 2
+	// 升级状态：升级失败
+	UpgradeStatus_Failed UpgradeStatusCode = 3
+	// 升级状态：重启中
+	UpgradeStatus_Rebooting UpgradeStatusCode = 4
+)
+
+// UpgradeStatus 升级状态信息
+type UpgradeStatus struct {
+	Status    UpgradeStatusCode `json:"status"`    // 升级状态
+	Progress  int               `json:"progress"`  // 升级进度（0~100）
+	ErrorCode int               `json:"errorCode"` // 失败错误码（升级失败时有效）
+}
+
+// UpgradeCheck 升级包校验（检查大小与适用款型）
+//
+//	@param	params: 升级参数
+//	@return	设备基础信息
+//	@return	异常信息
+func (p *Manager) UpgradeCheck(params UpgradeParams) (*BaseInfoQueryReply, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureUpgrade); err != nil {
+		return nil, err
+	}
+
+	if params.Size <= 0 {
+		return nil, errors.New("upgrade package size required")
+	}
+	// 查询设备基础信息
+	baseInfo, err := p.BaseInfoQuery(101)
+	if err != nil {
+		return nil, err
+	}
+	// 检查大小
+	if baseInfo.PackageLimitSize > 0 {
+		unit := params.PackageLimitSizeUnit
+		if unit <= 0 {
+			unit = 1024 * 1024
+		}
+		limit := int64(baseInfo.PackageLimitSize) * unit
+		if params.Size > limit {
+			return baseInfo, fmt.Errorf("%w: %d > %d", ErrUpgradePackageTooLarge, params.Size, limit)
+		}
+	}
+	// 检查款型
+	if params.DevType != "" {
+		matched := strings.EqualFold(params.DevType, baseInfo.DevType)
+		for _, name := range strings.Split(baseInfo.FullDeviceType, "@") {
+			matched = matched || strings.EqualFold(params.DevType, strings.TrimSpace(name))
+		}
+		if !matched {
+			return baseInfo, fmt.Errorf("%w: package %s, device %s", ErrUpgradeDevTypeMismatch, params.DevType, baseInfo.DevType)
+		}
+	}
+	return baseInfo, nil
+}
+
+// 上传文件时刷新写超时并报告进度的读取器
+type uploadBodyReader struct {
+	r        io.Reader            // 文件内容
+	client   *httpconn.HttpClient // HTTP客户端
+	sent     int64                // 已发送字节数
+	total    int64                // 总字节数
+	onUpload func(sent, total int64)
+}
+
+// Read 读取数据
+func (r *uploadBodyReader) Read(p []byte) (int, error) {
+	if err := r.client.RefreshWriteDeadline(); err != nil {
+		return 0, err
+	}
+	n, err := r.r.Read(p)
+	r.sent += int64(n)
+	if n > 0 && r.onUpload != nil {
+		r.onUpload(r.sent, r.total)
+	}
+	return n, err
+}
+
+// Close 关闭（文件由调用方关闭）
+func (r *uploadBodyReader) Close() error {
+	return nil
+}
+
+// UpgradeReply 升级包上传响应
+type UpgradeReply = common.Response[common.ResponseStatus]
+
+// Upgrade 上传升级包并开始升级
+//
+// 上传前校验升级包大小与适用款型；上传完成后设备开始升级，请使用UpgradeWait等待升级结束。
+//
+//	@param	params: 升级参数
+//	@return	异常信息
+func (p *Manager) Upgrade(params UpgradeParams) error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureUpgrade); err != nil {
+		return err
+	}
+
+	// 校验升级包
+	if _, err := p.UpgradeCheck(params); err != nil {
+		return err
+	}
+
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	body := &uploadBodyReader{
+		r:        io.LimitReader(params.Package, params.Size),
+		client:   client,
+		total:    params.Size,
+		onUpload: params.OnUpload,
+	}
+	var reply UpgradeReply
+	_, err := client.Post("/SDCAPI/V1.0/System/Upgrade").
+		SetBody(body, params.Size).
+		SetContentType("application/octet-stream").
+		DecodeJSON(&reply)
+	if err != nil {
+		return err
+	}
+
+	// 检查状态码
+	if reply.ResponseStatus.StatusCode != 0 {
+		return errors.New(reply.ResponseStatus.StatusString)
+	}
+
+	// OK
+	return nil
+}
+
+// UpgradeStatusQuery 升级状态查询
+func (p *Manager) UpgradeStatusQuery() (*UpgradeStatus, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureUpgrade); err != nil {
+		return nil, err
+	}
+
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply UpgradeStatus
+	_, err := client.Get("/SDCAPI/V1.0/System/Upgrade/Status").
+		SetContentType("application/x-www-form-urlencoded").
+		DecodeJSON(&reply)
+	if err != nil {
+		return nil, err
+	}
+
+	// OK
+	return &reply, nil
+}
+
+// UpgradeWait 轮询升级状态直至升级结束
+//
+// 设备升级成功后会重启，连接断开时返回最后一次查询到的状态（不视为异常），
+// 调用方需等待设备重新注册。升级成功或重启中状态下连接5分钟内仍未断开时，返回最后一次查询到的状态（未重启）；
+// 请在升级包上传成功后调用，连续查询到空闲状态且进度未达100时视为升级未开始，返回包装了ErrUpgradeFailed的异常。
+//
+//	@param	ctx: 上下文
+//	@param	interval: 轮询间隔（小于等于0时默认为2秒）
+//	@param	onProgress: 进度回调（选填）
+//	@return	最后一次查询到的升级状态
+//	@return	是否因设备重启而断开连接
+//	@return	异常信息（升级失败时返回包装了ErrUpgradeFailed的异常）
+func (p *Manager) UpgradeWait(ctx context.Context, interval time.Duration, onProgress func(status UpgradeStatus)) (*UpgradeStatus, bool, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureUpgrade); err != nil {
+		return nil, false, err
+	}
+
+	if interval <= 0 {
+		interval = time.Second * 2
+	}
+	last := &UpgradeStatus{Status: UpgradeStatus_Upgrading}
+	failures := 0
+	idles := 0
+	var doneAt time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return last, false, ctx.Err()
+		case <-time.After(interval):
+		}
+		status, err := p.UpgradeStatusQuery()
+		if err != nil {
+			// 升级后期设备重启，连续查询失败视为已断开
+			failures++
+			if failures >= 3 || last.Status == UpgradeStatus_Success || last.Status == UpgradeStatus_Rebooting {
+				return last, true, nil
+			}
+			continue
+		}
+		failures = 0
+		last = status
+		if onProgress != nil {
+			onProgress(*status)
+		}
+		if status.Status != UpgradeStatus_Idle {
+			idles = 0
+		}
+		switch status.Status {
+		case UpgradeStatus_Failed:
+			return status, false, fmt.Errorf("%w: error code %d", ErrUpgradeFailed, status.ErrorCode)
+		case UpgradeStatus_Idle:
+			// 升级已结束且设备未重启
+			if status.Progress >= 100 {
+				return status, false, nil
+			}
+			// 升级未开始
+			idles++
+			if idles >= upgradeIdleMaxCount {
+				return status, false, fmt.Errorf("%w: upgrade not started (progress %d)", ErrUpgradeFailed, status.Progress)
+			}
+		case UpgradeStatus_Success, UpgradeStatus_Rebooting:
+			// 等待设备重启断开连接（有上限）
+			if doneAt.IsZero() {
+				doneAt = time.Now()
+			} else if time.Since(doneAt) >= upgradeRebootWait {
+				return status, false, nil
+			}
+		}
+	}
+}
@@ -0,0 +1,188 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口设备用户管理
+ */
+package device
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
+)
+
+// UserRole 用户角色
+type UserRole int
+
+const (
+	// 用户角色：管理员
+	UserRole_Admin UserRole = 0
+	// 用户角色：操作员
+	UserRole_Operator UserRole = 1
+	// 用户角色：普通用户
+	UserRole_Viewer UserRole = 2
+	// 用户角色：北向接口用户（如ApiAdmin）
+	UserRole_Api UserRole = 3
+)
+
+// UserInfo 用户信息
+type UserInfo struct {
+	UserName string   `json:"userName"` // 用户名
+	Role     UserRole `json:"role"`     // 用户角色
+	Locked   bool     `json:"locked"`   // 是否已锁定（密码多次错误）
+}
+
+// UserQueryReply 用户查询响应
+type UserQueryReply struct {
+	Users []UserInfo `json:"users"` // 用户列表
+}
+
+// UserQuery 用户查询
+func (p *Manager) UserQuery() (*UserQueryReply, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureUserManage); err != nil {
+		return nil, err
+	}
+
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply UserQueryReply
+	_, err := client.Get("/SDCAPI/V1.0/Users").
+		SetContentType("application/x-www-form-urlencoded").
+		DecodeJSON(&reply)
+	if err != nil {
+		return nil, err
+	}
+
+	// OK
+	return &reply, nil
+}
+
+// 校验密码（设备要求8~32位字符）
+func validateUserPassword(password string) error {
+	if len(password) < 8 || len(password) > 32 {
+		return fmt.Errorf("invalid password length: %d", len(password))
+	}
+	return nil
+}
+
+// UserAddParams 用户添加参数
+type UserAddParams struct {
+	UserName string   `json:"userName"` // 用户名，1~32位字符
+	Password string   `json:"password"` // 密码，8~32位字符
+	Role     UserRole `json:"role"`     // 用户角色
+}
+
+// UserSettingReply 用户管理响应
+type UserSettingReply = common.Response[common.ResponseStatus]
+
+// 检查用户管理响应
+func checkUserSettingReply(reply *UserSettingReply, err error) error {
+	if err != nil {
+		return err
+	}
+	if reply.ResponseStatus.StatusCode != 0 {
+		return errors.New(reply.ResponseStatus.StatusString)
+	}
+	return nil
+}
+
+// UserAdd 用户添加
+//
+//	@param	params: 用户添加参数
+func (p *Manager) UserAdd(params UserAddParams) error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureUserManage); err != nil {
+		return err
+	}
+
+	// 校验参数
+	if params.UserName == "" || len(params.UserName) > 32 {
+		return fmt.Errorf("invalid user name: %s", params.UserName)
+	}
+	if err := validateUserPassword(params.Password); err != nil {
+		return err
+	}
+
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply UserSettingReply
+	_, err := client.Post("/SDCAPI/V1.0/Users").
+		SetJSON(&params).
+		DecodeJSON(&reply)
+	return checkUserSettingReply(&reply, err)
+}
+
+// UserDelete 用户删除
+//
+//	@param	userName: 用户名
+func (p *Manager) UserDelete(userName string) error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureUserManage); err != nil {
+		return err
+	}
+
+	if userName == "" {
+		return errors.New("user name required")
+	}
+
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply UserSettingReply
+	_, err := client.Delete("/SDCAPI/V1.0/Users").
+		SetContentType("application/x-www-form-urlencoded").
+		SetQuery("userName", userName).
+		DecodeJSON(&reply)
+	return checkUserSettingReply(&reply, err)
+}
+
+// UserPasswordParams 用户密码修改参数
+type UserPasswordParams struct {
+	UserName    string `json:"userName"`    // 用户名
+	OldPassword string `json:"oldPassword"` // 原密码
+	NewPassword string `json:"newPassword"` // 新密码，8~32位字符
+}
+
+// UserPasswordChange 用户密码修改
+//
+// 修改当前连接使用的用户的密码后，请同步修改会话的认证信息（SetAuthorization），否则后续请求将认证失败。
+//
+//	@param	params: 用户密码修改参数
+func (p *Manager) UserPasswordChange(params UserPasswordParams) error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureUserManage); err != nil {
+		return err
+	}
+
+	// 校验参数
+	if params.UserName == "" {
+		return errors.New("user name required")
+	}
+	if err := validateUserPassword(params.NewPassword); err != nil {
+		return err
+	}
+	if params.NewPassword == params.OldPassword {
+		return errors.New("new password must differ from old password")
+	}
+
+	// 获取Socket连接
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply UserSettingReply
+	_, err := client.Put("/SDCAPI/V1.0/Users/Password").
+		SetJSON(&params).
+		DecodeJSON(&reply)
+	return checkUserSettingReply(&reply, err)
+}
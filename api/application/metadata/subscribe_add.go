@@ -45,6 +45,11 @@ type SubscribeAddReply = common.Response[SubscribeAddReplyData]
 //	@param params: 订阅添加参数
 //	@return 订阅ID
 func (p *Manager) SubscribeAdd(params SubscribeAddParams) (int, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureMetadata); err != nil {
+		return 0, err
+	}
+
 	// 获取Socket连接
 	client := p.connInstance.LockHttpClient()
 	defer p.connInstance.Unlock()
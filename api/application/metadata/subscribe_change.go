@@ -35,6 +35,11 @@ type SubscribeChangeReply = common.Response[common.ResponseStatus]
 //	@param params: 订阅参数
 //	@return 订阅ID
 func (p *Manager) SubscribeChange(params SubscribeChangeParams) error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureMetadata); err != nil {
+		return err
+	}
+
 	// 获取Socket连接
 	client := p.connInstance.LockHttpClient()
 	defer p.connInstance.Unlock()
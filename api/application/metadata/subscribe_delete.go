@@ -33,6 +33,11 @@ type SubscribeDeleteReply = common.Response[common.ResponseStatus]
 //	@param params: 订阅删除参数
 //	@return 异常信息
 func (p *Manager) SubscribeDelete(params ...SubscribeDeleteParam) error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureMetadata); err != nil {
+		return err
+	}
+
 	// 获取客户端
 	client := p.connInstance.LockHttpClient()
 	defer p.connInstance.Unlock()
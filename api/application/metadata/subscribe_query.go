@@ -8,6 +8,8 @@ package metadata
 import (
 	"net/url"
 	"strconv"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
 )
 
 // SubscribeQueryParam 订阅查询参数构建器
@@ -34,6 +36,11 @@ type SubscribeQueryReply struct {
 //	@return 订阅查询结果
 //	@return 异常信息
 func (p *Manager) SubscribeQuery(params ...SubscribeQueryParam) (*SubscribeQueryReply, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureMetadata); err != nil {
+		return nil, err
+	}
+
 	// 获取客户端
 	client := p.connInstance.LockHttpClient()
 	defer p.connInstance.Unlock()
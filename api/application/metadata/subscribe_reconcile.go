@@ -0,0 +1,127 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口智能元数据订阅调和
+ */
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SubscribeReconcileResult 智能元数据订阅调和结果
+type SubscribeReconcileResult struct {
+	Added     []int // 新增的订阅ID
+	Changed   []int // 修改（含刷新剩余时间）的订阅ID
+	Deleted   []int // 删除的订阅ID（过期地址或重复订阅）
+	Unchanged []int // 无需变更的订阅ID
+}
+
+// 订阅唯一标识（通知接收地址、端口、URL均一致的订阅视为同一订阅）
+func subscribeReconcileKey(info *SubscribeBaseInfo) string {
+	return fmt.Sprintf("%s|%d|%s", strings.ToLower(strings.TrimSpace(info.Address)), info.Port, strings.TrimSpace(info.MetaDataURL))
+}
+
+// 设备上的订阅是否需要修改
+func subscribeReconcileNeedChange(current *SubscribeInfo, desired *SubscribeAddParams) bool {
+	// 有时效的订阅每次调和都需要刷新剩余时间
+	if desired.TimeOut != 0 {
+		return true
+	}
+	// 永久订阅仅在参数不一致时修改
+	return current.TimeOut != 0 ||
+		current.HttpsEnable != desired.HttpsEnable ||
+		current.NeedUploadPic != desired.NeedUploadPic
+}
+
+// SubscribeReconcile 智能元数据订阅调和
+//
+// 以期望的订阅集合为准，对比设备当前订阅（SubscribeQuery），通过新增、修改、删除操作使设备订阅收敛到期望状态：
+//
+//	1、通知接收地址、端口、URL均一致的订阅视为同一订阅，设备上重复的订阅仅保留一个；
+//	2、参数不一致或有时效（TimeOut非0）的订阅执行修改，有时效的订阅借此刷新剩余时间；
+//	3、不在期望集合中的订阅（如设备重新注册后指向旧地址的订阅）将被删除；
+//	4、期望集合中设备上不存在的订阅将被新增。
+//
+// 设备不会返回订阅的认证用户名与密码，因此仅修改认证信息时请使用SubscribeChange。
+// 单个操作失败不会中断调和，全部错误会合并返回，同时返回已完成的调和结果。
+//
+//	@param desired: 期望的订阅集合
+//	@return 调和结果
+//	@return 异常信息
+func (p *Manager) SubscribeReconcile(desired []SubscribeAddParams) (*SubscribeReconcileResult, error) {
+	// 查询设备当前订阅
+	current, err := p.SubscribeQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	// 期望订阅去重（相同标识以最后一个为准）
+	desiredKeys := make([]string, 0, len(desired))
+	desiredMap := make(map[string]*SubscribeAddParams, len(desired))
+	for i := range desired {
+		key := subscribeReconcileKey(&desired[i].SubscribeBaseInfo)
+		if _, ok := desiredMap[key]; !ok {
+			desiredKeys = append(desiredKeys, key)
+		}
+		desiredMap[key] = &desired[i]
+	}
+
+	// 对比设备当前订阅
+	var errs []error
+	result := &SubscribeReconcileResult{}
+	matched := make(map[string]bool, len(desiredMap))
+	for i := range current.Subscriptions {
+		info := &current.Subscriptions[i]
+		key := subscribeReconcileKey(&info.SubscribeBaseInfo)
+		want, ok := desiredMap[key]
+		// 不在期望集合中或重复的订阅需要删除
+		if !ok || matched[key] {
+			if err := p.SubscribeDelete(SubscribeDeleteWithID(info.ID)); err != nil {
+				errs = append(errs, fmt.Errorf("delete subscription %d: %w", info.ID, err))
+				continue
+			}
+			result.Deleted = append(result.Deleted, info.ID)
+			continue
+		}
+		matched[key] = true
+		// 参数一致的永久订阅无需变更
+		if !subscribeReconcileNeedChange(info, want) {
+			result.Unchanged = append(result.Unchanged, info.ID)
+			continue
+		}
+		// 修改订阅
+		err := p.SubscribeChange(SubscribeChangeParams{
+			SubscribeInfo: SubscribeInfo{
+				ID:                info.ID,
+				SubscribeBaseInfo: want.SubscribeBaseInfo,
+			},
+			DigUserName: want.DigUserName,
+			DigUserPwd:  want.DigUserPwd,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("change subscription %d: %w", info.ID, err))
+			continue
+		}
+		result.Changed = append(result.Changed, info.ID)
+	}
+
+	// 新增设备上不存在的订阅
+	for _, key := range desiredKeys {
+		if matched[key] {
+			continue
+		}
+		want := desiredMap[key]
+		id, err := p.SubscribeAdd(*want)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("add subscription %s:%d: %w", want.Address, want.Port, err))
+			continue
+		}
+		result.Added = append(result.Added, id)
+	}
+
+	// OK
+	return result, errors.Join(errs...)
+}
@@ -31,7 +31,7 @@ type SubscribeTargetUploadSubImageInfo struct {
 
 	// 图像类型
 	//	取值范围：15-全景图，11-目标图，10-目标整体图
-	ImageType int64 `json:"imageType"`
+	ImageType ImageType `json:"imageType"`
 
 	// 抓拍时间（UTC）
 	// 	格式：YYMMDDhhmmssSSS，
@@ -75,7 +75,7 @@ type SubscribeTargetUploadDetailInfo struct {
 	// 	1 - 目标抓拍
 	// 	2 - 目标识别
 	// 	53 - 骑行人
-	TargetType int64 `json:"targetType"`
+	TargetType TargetType `json:"targetType"`
 
 	// 目标ID，编码格式：设备编码+抓拍时间YYMMDDhhmmssSSS+目标ID（HEX编码）
 	//	其中：
@@ -97,7 +97,7 @@ type SubscribeTargetUploadDetailInfo struct {
 
 	// 目标库中匹配上的人员的证件类型
 	// 	取值范围：0-身份证，1-护照，2-军官证，3-驾驶证，4-其他
-	IDType int64 `json:"IDType"`
+	IDType IDType `json:"IDType"`
 
 	// 目标库中匹配上的人员的证件号
 	// 	最多支持31位字符（字母，数字和汉字），每个汉字占3位字符
@@ -117,7 +117,7 @@ type SubscribeTargetUploadDetailInfo struct {
 
 	// 人员性别
 	// 	取值范围：0-未识别，1-女，2-男
-	GenderCode int64 `json:"genderCode"`
+	GenderCode GenderCode `json:"genderCode"`
 
 	// 年龄上限
 	AgeUpLimit int64 `json:"ageUpLimit"`
@@ -127,93 +127,127 @@ type SubscribeTargetUploadDetailInfo struct {
 
 	// 发型
 	// 	取值范围：0-未识别，1-长头发，2-短头发，3-秃头
-	HairStyle int64 `json:"hairStyle"`
+	HairStyle HairStyle `json:"hairStyle"`
 
 	// 遮档(口罩)
 	// 	取值范围：0-未识别，1-未带口罩，2-戴口罩
-	HasRespirator int64 `json:"hasRespirator"`
+	HasRespirator Respirator `json:"hasRespirator"`
 
 	// 范佩戴口罩
 	// 	取值范围：0-未知，1-规范戴口罩，2-不规范戴口罩
-	MouthMaskStandard int64 `json:"mouthmaskStandard"`
+	MouthMaskStandard MouthMaskStandard `json:"mouthmaskStandard"`
 
 	// 戴帽子
 	// 	取值范围：0-未识别，1-未戴帽子，2-戴帽子
-	HasCap int64 `json:"hasCap"`
+	HasCap Cap `json:"hasCap"`
 
 	// 眼镜样式
 	// 	取值范围：0-未识别，1-未戴眼镜，2-戴普通眼镜，3-戴太阳眼镜
-	HasGlass int64 `json:"hasGlass"`
+	HasGlass Glasses `json:"hasGlass"`
 
 	// 上衣款式
 	// 	取值范围：0-未识别，1-长袖，2-短袖
-	UpperStyle int64 `json:"upperStyle"`
+	UpperStyle UpperStyle `json:"upperStyle"`
 
 	// 上衣颜色
 	// 	取值范围：0-未识别，1-黑，2-蓝，3-绿，4-白/灰，5-黄/橙/棕，6-红/粉/紫
-	UpperColor int64 `json:"upperColor"`
+	UpperColor Color `json:"upperColor"`
 
 	// 上衣纹理
 	// 	取值范围：0-未识别，1-纯色，2-条纹，3-格子
-	UpperTexture int64 `json:"upperTexture"`
+	UpperTexture Texture `json:"upperTexture"`
 
 	// 下衣款式
 	// 	取值范围：0-未识别，1-长裤，2-短裤，3-裙子
-	LowStyle int64 `json:"lowStyle"`
+	LowStyle LowerStyle `json:"lowStyle"`
 
 	// 下衣颜色
 	// 	取值范围：0-未识别，1-黑，2-蓝，3-绿，4-白/灰，5-黄/橙/棕，6-红/粉/紫
-	LowerColor int64 `json:"lowerColor"`
+	LowerColor Color `json:"lowerColor"`
 
 	// 体型
 	// 	取值范围：0-未识别，1-标准，2-胖，3-瘦
-	BodyType int64 `json:"bodyType"`
+	BodyType BodyType `json:"bodyType"`
 
 	// 背包
 	// 	取值范围：0-未识别，1-未背包，2-背包
-	HasBackpack int64 `json:"hasBackpack"`
+	HasBackpack Backpack `json:"hasBackpack"`
 
 	// 胡子
 	// 	取值范围：0-未识别，1-没有胡子，2-有胡子
-	HasMustache int64 `json:"hasMustache"`
+	HasMustache Mustache `json:"hasMustache"`
 
 	// 是否拎东西
 	// 	取值范围：0-未识别，1-未拎东西，2-拎东西
-	CarryBag int64 `json:"carryBag"`
+	CarryBag CarryBag `json:"carryBag"`
 
 	// 斜挎包
 	// 	取值范围：0-未识别，1-无斜挎包，2-有斜挎包
-	HasSatchel int64 `json:"hasSatchel"`
+	HasSatchel Satchel `json:"hasSatchel"`
 
 	// 前面背包
 	// 	取值范围：0-未识别，1-无前背包，2-有前背包
-	HasFrontBag int64 `json:"hasFrontBag"`
+	HasFrontBag FrontBag `json:"hasFrontBag"`
 
-	//
+	// 雨伞
 	// 	取值范围：0-未识别，1-无雨伞，2-有雨伞
-	HasUmbrella int64 `json:"hasUmbrella"`
+	HasUmbrella Umbrella `json:"hasUmbrella"`
 
 	// 行李箱
 	// 	取值范围：0-未识别，1-无行李箱，2-有行李箱
-	HasLuggage int64 `json:"hasLuggage"`
+	HasLuggage Luggage `json:"hasLuggage"`
 
 	// 行进方向
 	// 	取值范围：0-未识别，1-朝前，2-朝后
-	MoveDirection int64 `json:"moveDirection"`
+	MoveDirection MoveDirection `json:"moveDirection"`
 
 	// 行进速度
 	// 	取值范围：0-未识别，1-慢速，2-快速
-	MoveSpeed int64 `json:"moveSpeed"`
+	MoveSpeed MoveSpeed `json:"moveSpeed"`
 
 	// 朝向
 	// 	取值范围：0-未识别，1-朝前，2-朝后，3-朝左，4-朝右
-	HumanView int64 `json:"humanView"`
+	HumanView HumanView `json:"humanView"`
 
 	// 图片数据对象数组
 	// 	可能包含目标抠图、目标整体抠图、全景图
 	SubImageList []SubscribeTargetUploadSubImageInfo `json:"subImageList"`
 }
 
+// Validate 校验目标详细信息中的枚举值（未识别的值视为有效）
+func (p *SubscribeTargetUploadDetailInfo) Validate() error {
+	errs := []error{
+		p.TargetType.Validate(),
+		p.IDType.Validate(),
+		p.GenderCode.Validate(),
+		p.HairStyle.Validate(),
+		p.HasRespirator.Validate(),
+		p.MouthMaskStandard.Validate(),
+		p.HasCap.Validate(),
+		p.HasGlass.Validate(),
+		p.UpperStyle.Validate(),
+		p.UpperColor.Validate(),
+		p.UpperTexture.Validate(),
+		p.LowStyle.Validate(),
+		p.LowerColor.Validate(),
+		p.BodyType.Validate(),
+		p.HasBackpack.Validate(),
+		p.HasMustache.Validate(),
+		p.CarryBag.Validate(),
+		p.HasSatchel.Validate(),
+		p.HasFrontBag.Validate(),
+		p.HasUmbrella.Validate(),
+		p.HasLuggage.Validate(),
+		p.MoveDirection.Validate(),
+		p.MoveSpeed.Validate(),
+		p.HumanView.Validate(),
+	}
+	for _, img := range p.SubImageList {
+		errs = append(errs, img.ImageType.Validate())
+	}
+	return errors.Join(errs...)
+}
+
 // SubscribeTargetUploadInfo 元数据订阅目标数据上报信息
 type SubscribeTargetUploadInfo struct {
 	Common     SubscribeUploadCommonInfo         `json:"common"`     // 元数据通用信息
@@ -225,8 +259,19 @@ type SubscribeTargetUploadParams struct {
 	Metadata SubscribeTargetUploadInfo `json:"metadataObject"` // 元数据上报信息
 }
 
-// SubscribeTargetUpload 元数据订阅目标数据上报（HTTP响应已被接管，请不要再发送任何响应）
-func SubscribeTargetUpload(w http.ResponseWriter, r *http.Request) (*SubscribeTargetUploadParams, error) {
+// DecodeSubscribeTargetUpload 解析元数据订阅目标数据上报请求体
+//
+// 可用于解析从持久化队列中取出的原始上报数据
+func DecodeSubscribeTargetUpload(body []byte) (*SubscribeTargetUploadParams, error) {
+	var params SubscribeTargetUploadParams
+	if err := json.Unmarshal(body, &params); err != nil {
+		return nil, err
+	}
+	return &params, nil
+}
+
+// 读取上报请求体
+func readSubscribeUploadBody(r *http.Request) ([]byte, error) {
 	// 检查请求体
 	if r.Body == nil {
 		return nil, errors.New("request body is empty")
@@ -234,14 +279,20 @@ func SubscribeTargetUpload(w http.ResponseWriter, r *http.Request) (*SubscribeTa
 	// 延迟关闭请求体
 	defer r.Body.Close()
 	// 读取请求
-	body, err := io.ReadAll(r.Body)
+	return io.ReadAll(r.Body)
+}
+
+// SubscribeTargetUpload 元数据订阅目标数据上报（HTTP响应已被接管，请不要再发送任何响应）
+func SubscribeTargetUpload(w http.ResponseWriter, r *http.Request) (*SubscribeTargetUploadParams, error) {
+	// 读取请求
+	body, err := readSubscribeUploadBody(r)
 	if err != nil {
 		return nil, err
 	}
 
 	// 解析请求参数
-	var params SubscribeTargetUploadParams
-	if err := json.Unmarshal(body, &params); err != nil {
+	params, err := DecodeSubscribeTargetUpload(body)
+	if err != nil {
 		return nil, err
 	}
 
@@ -250,5 +301,51 @@ func SubscribeTargetUpload(w http.ResponseWriter, r *http.Request) (*SubscribeTa
 	w.Write([]byte("OK"))
 
 	// OK
-	return &params, nil
+	return params, nil
+}
+
+// SubscribeUploadQueue 元数据上报持久化队列接口（如diskqueue.Queue）
+type SubscribeUploadQueue interface {
+	// 写入原始上报数据，返回消息序号
+	Append(data []byte) (uint64, error)
+}
+
+// SubscribeTargetUploadEnqueue 元数据订阅目标数据上报，并写入持久化队列（HTTP响应已被接管，请不要再发送任何响应）
+//
+// 上报数据写入队列成功后才向摄像机响应成功；写入失败（如下游长时间不可用导致队列已满）时响应503，
+// 由摄像机稍后重传。下游消费者从队列中读取原始上报数据后，使用DecodeSubscribeTargetUpload解析。
+//
+//	@param w: HTTP响应写入器
+//	@param r: HTTP请求
+//	@param queue: 持久化队列
+//	@return 消息序号
+//	@return 异常信息
+func SubscribeTargetUploadEnqueue(w http.ResponseWriter, r *http.Request, queue SubscribeUploadQueue) (uint64, error) {
+	// 读取请求
+	body, err := readSubscribeUploadBody(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return 0, err
+	}
+
+	// 检查请求参数，避免无效数据进入队列
+	if !json.Valid(body) {
+		err = errors.New("invalid metadata upload body")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return 0, err
+	}
+
+	// 写入队列
+	seq, err := queue.Append(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return 0, err
+	}
+
+	// 响应成功状态码
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("OK"))
+
+	// OK
+	return seq, nil
 }
@@ -0,0 +1,1462 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口智能元数据目标属性枚举
+ */
+package metadata
+
+import (
+	"github.com/kaicen-x/holosens-sdc-sdk/api/details/itgt/target/recognize"
+	"github.com/kaicen-x/holosens-sdc-sdk/pkg/enumlabel"
+)
+
+// IDType 证件类型（与目标库记录的证件类型一致）
+type IDType = recognize.CardType
+
+// TargetType 元数据类型
+type TargetType int64
+
+// 元数据类型枚举
+const (
+	TargetType_Capture   TargetType = 1  // 目标抓拍
+	TargetType_Recognize TargetType = 2  // 目标识别
+	TargetType_Rider     TargetType = 53 // 骑行人
+)
+
+// 元数据类型标签表
+var targetTypeTable = enumlabel.NewTable("targetType",
+	enumlabel.Item[TargetType]{Code: TargetType_Capture, Zh: "目标抓拍", En: "target capture"},
+	enumlabel.Item[TargetType]{Code: TargetType_Recognize, Zh: "目标识别", En: "target recognition"},
+	enumlabel.Item[TargetType]{Code: TargetType_Rider, Zh: "骑行人", En: "rider"},
+)
+
+// TargetTypeItems 获取元数据类型全部枚举项
+func TargetTypeItems() []enumlabel.Item[TargetType] {
+	return targetTypeTable.Items()
+}
+
+// String 获取中文标签
+func (v TargetType) String() string {
+	return targetTypeTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v TargetType) Label(lang enumlabel.Lang) string {
+	return targetTypeTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v TargetType) Valid() bool {
+	return targetTypeTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v TargetType) Validate() error {
+	return targetTypeTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v TargetType) Labeled() enumlabel.Value {
+	return targetTypeTable.Value(v)
+}
+
+// MarshalJSON 序列化为同时包含枚举值与中英文标签的JSON对象
+func (v TargetType) MarshalJSON() ([]byte, error) {
+	return targetTypeTable.Marshal(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *TargetType) UnmarshalJSON(data []byte) error {
+	code, err := targetTypeTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
+
+// ImageType 图像类型
+type ImageType int64
+
+// 图像类型枚举
+const (
+	ImageType_TargetBody ImageType = 10 // 目标整体图
+	ImageType_Target     ImageType = 11 // 目标图
+	ImageType_Panorama   ImageType = 15 // 全景图
+)
+
+// 图像类型标签表
+var imageTypeTable = enumlabel.NewTable("imageType",
+	enumlabel.Item[ImageType]{Code: ImageType_TargetBody, Zh: "目标整体图", En: "target body image"},
+	enumlabel.Item[ImageType]{Code: ImageType_Target, Zh: "目标图", En: "target image"},
+	enumlabel.Item[ImageType]{Code: ImageType_Panorama, Zh: "全景图", En: "panorama image"},
+)
+
+// ImageTypeItems 获取图像类型全部枚举项
+func ImageTypeItems() []enumlabel.Item[ImageType] {
+	return imageTypeTable.Items()
+}
+
+// String 获取中文标签
+func (v ImageType) String() string {
+	return imageTypeTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v ImageType) Label(lang enumlabel.Lang) string {
+	return imageTypeTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v ImageType) Valid() bool {
+	return imageTypeTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v ImageType) Validate() error {
+	return imageTypeTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v ImageType) Labeled() enumlabel.Value {
+	return imageTypeTable.Value(v)
+}
+
+// MarshalJSON 序列化为同时包含枚举值与中英文标签的JSON对象
+func (v ImageType) MarshalJSON() ([]byte, error) {
+	return imageTypeTable.Marshal(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *ImageType) UnmarshalJSON(data []byte) error {
+	code, err := imageTypeTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
+
+// GenderCode 人员性别
+type GenderCode int64
+
+// 人员性别枚举
+const (
+	GenderCode_Unknown GenderCode = 0 // 未识别
+	GenderCode_Female  GenderCode = 1 // 女
+	GenderCode_Male    GenderCode = 2 // 男
+)
+
+// 人员性别标签表
+var genderCodeTable = enumlabel.NewTable("genderCode",
+	enumlabel.Item[GenderCode]{Code: GenderCode_Unknown, Zh: "未识别", En: "unknown"},
+	enumlabel.Item[GenderCode]{Code: GenderCode_Female, Zh: "女", En: "female"},
+	enumlabel.Item[GenderCode]{Code: GenderCode_Male, Zh: "男", En: "male"},
+)
+
+// GenderCodeItems 获取人员性别全部枚举项
+func GenderCodeItems() []enumlabel.Item[GenderCode] {
+	return genderCodeTable.Items()
+}
+
+// String 获取中文标签
+func (v GenderCode) String() string {
+	return genderCodeTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v GenderCode) Label(lang enumlabel.Lang) string {
+	return genderCodeTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v GenderCode) Valid() bool {
+	return genderCodeTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v GenderCode) Validate() error {
+	return genderCodeTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v GenderCode) Labeled() enumlabel.Value {
+	return genderCodeTable.Value(v)
+}
+
+// MarshalJSON 序列化为同时包含枚举值与中英文标签的JSON对象
+func (v GenderCode) MarshalJSON() ([]byte, error) {
+	return genderCodeTable.Marshal(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *GenderCode) UnmarshalJSON(data []byte) error {
+	code, err := genderCodeTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
+
+// HairStyle 发型
+type HairStyle int64
+
+// 发型枚举
+const (
+	HairStyle_Unknown HairStyle = 0 // 未识别
+	HairStyle_Long    HairStyle = 1 // 长头发
+	HairStyle_Short   HairStyle = 2 // 短头发
+	HairStyle_Bald    HairStyle = 3 // 秃头
+)
+
+// 发型标签表
+var hairStyleTable = enumlabel.NewTable("hairStyle",
+	enumlabel.Item[HairStyle]{Code: HairStyle_Unknown, Zh: "未识别", En: "unknown"},
+	enumlabel.Item[HairStyle]{Code: HairStyle_Long, Zh: "长头发", En: "long hair"},
+	enumlabel.Item[HairStyle]{Code: HairStyle_Short, Zh: "短头发", En: "short hair"},
+	enumlabel.Item[HairStyle]{Code: HairStyle_Bald, Zh: "秃头", En: "bald"},
+)
+
+// HairStyleItems 获取发型全部枚举项
+func HairStyleItems() []enumlabel.Item[HairStyle] {
+	return hairStyleTable.Items()
+}
+
+// String 获取中文标签
+func (v HairStyle) String() string {
+	return hairStyleTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v HairStyle) Label(lang enumlabel.Lang) string {
+	return hairStyleTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v HairStyle) Valid() bool {
+	return hairStyleTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v HairStyle) Validate() error {
+	return hairStyleTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v HairStyle) Labeled() enumlabel.Value {
+	return hairStyleTable.Value(v)
+}
+
+// MarshalJSON 序列化为同时包含枚举值与中英文标签的JSON对象
+func (v HairStyle) MarshalJSON() ([]byte, error) {
+	return hairStyleTable.Marshal(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *HairStyle) UnmarshalJSON(data []byte) error {
+	code, err := hairStyleTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
+
+// Respirator 遮挡（口罩）
+type Respirator int64
+
+// 遮挡（口罩）枚举
+const (
+	Respirator_Unknown Respirator = 0 // 未识别
+	Respirator_None    Respirator = 1 // 未戴口罩
+	Respirator_Wearing Respirator = 2 // 戴口罩
+)
+
+// 遮挡（口罩）标签表
+var respiratorTable = enumlabel.NewTable("respirator",
+	enumlabel.Item[Respirator]{Code: Respirator_Unknown, Zh: "未识别", En: "unknown"},
+	enumlabel.Item[Respirator]{Code: Respirator_None, Zh: "未戴口罩", En: "no mask"},
+	enumlabel.Item[Respirator]{Code: Respirator_Wearing, Zh: "戴口罩", En: "wearing mask"},
+)
+
+// RespiratorItems 获取遮挡（口罩）全部枚举项
+func RespiratorItems() []enumlabel.Item[Respirator] {
+	return respiratorTable.Items()
+}
+
+// String 获取中文标签
+func (v Respirator) String() string {
+	return respiratorTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v Respirator) Label(lang enumlabel.Lang) string {
+	return respiratorTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v Respirator) Valid() bool {
+	return respiratorTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v Respirator) Validate() error {
+	return respiratorTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v Respirator) Labeled() enumlabel.Value {
+	return respiratorTable.Value(v)
+}
+
+// MarshalJSON 序列化为同时包含枚举值与中英文标签的JSON对象
+func (v Respirator) MarshalJSON() ([]byte, error) {
+	return respiratorTable.Marshal(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *Respirator) UnmarshalJSON(data []byte) error {
+	code, err := respiratorTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
+
+// MouthMaskStandard 规范佩戴口罩
+type MouthMaskStandard int64
+
+// 规范佩戴口罩枚举
+const (
+	MouthMaskStandard_Unknown     MouthMaskStandard = 0 // 未知
+	MouthMaskStandard_Standard    MouthMaskStandard = 1 // 规范戴口罩
+	MouthMaskStandard_NonStandard MouthMaskStandard = 2 // 不规范戴口罩
+)
+
+// 规范佩戴口罩标签表
+var mouthMaskStandardTable = enumlabel.NewTable("mouthMaskStandard",
+	enumlabel.Item[MouthMaskStandard]{Code: MouthMaskStandard_Unknown, Zh: "未知", En: "unknown"},
+	enumlabel.Item[MouthMaskStandard]{Code: MouthMaskStandard_Standard, Zh: "规范戴口罩", En: "properly worn"},
+	enumlabel.Item[MouthMaskStandard]{Code: MouthMaskStandard_NonStandard, Zh: "不规范戴口罩", En: "improperly worn"},
+)
+
+// MouthMaskStandardItems 获取规范佩戴口罩全部枚举项
+func MouthMaskStandardItems() []enumlabel.Item[MouthMaskStandard] {
+	return mouthMaskStandardTable.Items()
+}
+
+// String 获取中文标签
+func (v MouthMaskStandard) String() string {
+	return mouthMaskStandardTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v MouthMaskStandard) Label(lang enumlabel.Lang) string {
+	return mouthMaskStandardTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v MouthMaskStandard) Valid() bool {
+	return mouthMaskStandardTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v MouthMaskStandard) Validate() error {
+	return mouthMaskStandardTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v MouthMaskStandard) Labeled() enumlabel.Value {
+	return mouthMaskStandardTable.Value(v)
+}
+
+// MarshalJSON 序列化为同时包含枚举值与中英文标签的JSON对象
+func (v MouthMaskStandard) MarshalJSON() ([]byte, error) {
+	return mouthMaskStandardTable.Marshal(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *MouthMaskStandard) UnmarshalJSON(data []byte) error {
+	code, err := mouthMaskStandardTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
+
+// Cap 戴帽子
+type Cap int64
+
+// 戴帽子枚举
+const (
+	Cap_Unknown Cap = 0 // 未识别
+	Cap_None    Cap = 1 // 未戴帽子
+	Cap_Wearing Cap = 2 // 戴帽子
+)
+
+// 戴帽子标签表
+var capTable = enumlabel.NewTable("cap",
+	enumlabel.Item[Cap]{Code: Cap_Unknown, Zh: "未识别", En: "unknown"},
+	enumlabel.Item[Cap]{Code: Cap_None, Zh: "未戴帽子", En: "no cap"},
+	enumlabel.Item[Cap]{Code: Cap_Wearing, Zh: "戴帽子", En: "wearing cap"},
+)
+
+// CapItems 获取戴帽子全部枚举项
+func CapItems() []enumlabel.Item[Cap] {
+	return capTable.Items()
+}
+
+// String 获取中文标签
+func (v Cap) String() string {
+	return capTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v Cap) Label(lang enumlabel.Lang) string {
+	return capTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v Cap) Valid() bool {
+	return capTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v Cap) Validate() error {
+	return capTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v Cap) Labeled() enumlabel.Value {
+	return capTable.Value(v)
+}
+
+// MarshalJSON 序列化为同时包含枚举值与中英文标签的JSON对象
+func (v Cap) MarshalJSON() ([]byte, error) {
+	return capTable.Marshal(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *Cap) UnmarshalJSON(data []byte) error {
+	code, err := capTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
+
+// Glasses 眼镜样式
+type Glasses int64
+
+// 眼镜样式枚举
+const (
+	Glasses_Unknown Glasses = 0 // 未识别
+	Glasses_None    Glasses = 1 // 未戴眼镜
+	Glasses_Normal  Glasses = 2 // 戴普通眼镜
+	Glasses_Sun     Glasses = 3 // 戴太阳眼镜
+)
+
+// 眼镜样式标签表
+var glassesTable = enumlabel.NewTable("glasses",
+	enumlabel.Item[Glasses]{Code: Glasses_Unknown, Zh: "未识别", En: "unknown"},
+	enumlabel.Item[Glasses]{Code: Glasses_None, Zh: "未戴眼镜", En: "no glasses"},
+	enumlabel.Item[Glasses]{Code: Glasses_Normal, Zh: "戴普通眼镜", En: "glasses"},
+	enumlabel.Item[Glasses]{Code: Glasses_Sun, Zh: "戴太阳眼镜", En: "sunglasses"},
+)
+
+// GlassesItems 获取眼镜样式全部枚举项
+func GlassesItems() []enumlabel.Item[Glasses] {
+	return glassesTable.Items()
+}
+
+// String 获取中文标签
+func (v Glasses) String() string {
+	return glassesTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v Glasses) Label(lang enumlabel.Lang) string {
+	return glassesTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v Glasses) Valid() bool {
+	return glassesTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v Glasses) Validate() error {
+	return glassesTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v Glasses) Labeled() enumlabel.Value {
+	return glassesTable.Value(v)
+}
+
+// MarshalJSON 序列化为同时包含枚举值与中英文标签的JSON对象
+func (v Glasses) MarshalJSON() ([]byte, error) {
+	return glassesTable.Marshal(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *Glasses) UnmarshalJSON(data []byte) error {
+	code, err := glassesTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
+
+// UpperStyle 上衣款式
+type UpperStyle int64
+
+// 上衣款式枚举
+const (
+	UpperStyle_Unknown     UpperStyle = 0 // 未识别
+	UpperStyle_LongSleeve  UpperStyle = 1 // 长袖
+	UpperStyle_ShortSleeve UpperStyle = 2 // 短袖
+)
+
+// 上衣款式标签表
+var upperStyleTable = enumlabel.NewTable("upperStyle",
+	enumlabel.Item[UpperStyle]{Code: UpperStyle_Unknown, Zh: "未识别", En: "unknown"},
+	enumlabel.Item[UpperStyle]{Code: UpperStyle_LongSleeve, Zh: "长袖", En: "long sleeves"},
+	enumlabel.Item[UpperStyle]{Code: UpperStyle_ShortSleeve, Zh: "短袖", En: "short sleeves"},
+)
+
+// UpperStyleItems 获取上衣款式全部枚举项
+func UpperStyleItems() []enumlabel.Item[UpperStyle] {
+	return upperStyleTable.Items()
+}
+
+// String 获取中文标签
+func (v UpperStyle) String() string {
+	return upperStyleTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v UpperStyle) Label(lang enumlabel.Lang) string {
+	return upperStyleTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v UpperStyle) Valid() bool {
+	return upperStyleTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v UpperStyle) Validate() error {
+	return upperStyleTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v UpperStyle) Labeled() enumlabel.Value {
+	return upperStyleTable.Value(v)
+}
+
+// MarshalJSON 序列化为同时包含枚举值与中英文标签的JSON对象
+func (v UpperStyle) MarshalJSON() ([]byte, error) {
+	return upperStyleTable.Marshal(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *UpperStyle) UnmarshalJSON(data []byte) error {
+	code, err := upperStyleTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
+
+// Color 衣着颜色
+type Color int64
+
+// 衣着颜色枚举
+const (
+	Color_Unknown           Color = 0 // 未识别
+	Color_Black             Color = 1 // 黑
+	Color_Blue              Color = 2 // 蓝
+	Color_Green             Color = 3 // 绿
+	Color_WhiteGray         Color = 4 // 白/灰
+	Color_YellowOrangeBrown Color = 5 // 黄/橙/棕
+	Color_RedPinkPurple     Color = 6 // 红/粉/紫
+)
+
+// 衣着颜色标签表
+var colorTable = enumlabel.NewTable("color",
+	enumlabel.Item[Color]{Code: Color_Unknown, Zh: "未识别", En: "unknown"},
+	enumlabel.Item[Color]{Code: Color_Black, Zh: "黑", En: "black"},
+	enumlabel.Item[Color]{Code: Color_Blue, Zh: "蓝", En: "blue"},
+	enumlabel.Item[Color]{Code: Color_Green, Zh: "绿", En: "green"},
+	enumlabel.Item[Color]{Code: Color_WhiteGray, Zh: "白/灰", En: "white/gray"},
+	enumlabel.Item[Color]{Code: Color_YellowOrangeBrown, Zh: "黄/橙/棕", En: "yellow/orange/brown"},
+	enumlabel.Item[Color]{Code: Color_RedPinkPurple, Zh: "红/粉/紫", En: "red/pink/purple"},
+)
+
+// ColorItems 获取衣着颜色全部枚举项
+func ColorItems() []enumlabel.Item[Color] {
+	return colorTable.Items()
+}
+
+// String 获取中文标签
+func (v Color) String() string {
+	return colorTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v Color) Label(lang enumlabel.Lang) string {
+	return colorTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v Color) Valid() bool {
+	return colorTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v Color) Validate() error {
+	return colorTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v Color) Labeled() enumlabel.Value {
+	return colorTable.Value(v)
+}
+
+// MarshalJSON 序列化为同时包含枚举值与中英文标签的JSON对象
+func (v Color) MarshalJSON() ([]byte, error) {
+	return colorTable.Marshal(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *Color) UnmarshalJSON(data []byte) error {
+	code, err := colorTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
+
+// Texture 上衣纹理
+type Texture int64
+
+// 上衣纹理枚举
+const (
+	Texture_Unknown Texture = 0 // 未识别
+	Texture_Solid   Texture = 1 // 纯色
+	Texture_Stripe  Texture = 2 // 条纹
+	Texture_Plaid   Texture = 3 // 格子
+)
+
+// 上衣纹理标签表
+var textureTable = enumlabel.NewTable("texture",
+	enumlabel.Item[Texture]{Code: Texture_Unknown, Zh: "未识别", En: "unknown"},
+	enumlabel.Item[Texture]{Code: Texture_Solid, Zh: "纯色", En: "solid"},
+	enumlabel.Item[Texture]{Code: Texture_Stripe, Zh: "条纹", En: "striped"},
+	enumlabel.Item[Texture]{Code: Texture_Plaid, Zh: "格子", En: "plaid"},
+)
+
+// TextureItems 获取上衣纹理全部枚举项
+func TextureItems() []enumlabel.Item[Texture] {
+	return textureTable.Items()
+}
+
+// String 获取中文标签
+func (v Texture) String() string {
+	return textureTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v Texture) Label(lang enumlabel.Lang) string {
+	return textureTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v Texture) Valid() bool {
+	return textureTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v Texture) Validate() error {
+	return textureTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v Texture) Labeled() enumlabel.Value {
+	return textureTable.Value(v)
+}
+
+// MarshalJSON 序列化为同时包含枚举值与中英文标签的JSON对象
+func (v Texture) MarshalJSON() ([]byte, error) {
+	return textureTable.Marshal(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *Texture) UnmarshalJSON(data []byte) error {
+	code, err := textureTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
+
+// LowerStyle 下衣款式
+type LowerStyle int64
+
+// 下衣款式枚举
+const (
+	LowerStyle_Unknown  LowerStyle = 0 // 未识别
+	LowerStyle_Trousers LowerStyle = 1 // 长裤
+	LowerStyle_Shorts   LowerStyle = 2 // 短裤
+	LowerStyle_Skirt    LowerStyle = 3 // 裙子
+)
+
+// 下衣款式标签表
+var lowerStyleTable = enumlabel.NewTable("lowerStyle",
+	enumlabel.Item[LowerStyle]{Code: LowerStyle_Unknown, Zh: "未识别", En: "unknown"},
+	enumlabel.Item[LowerStyle]{Code: LowerStyle_Trousers, Zh: "长裤", En: "trousers"},
+	enumlabel.Item[LowerStyle]{Code: LowerStyle_Shorts, Zh: "短裤", En: "shorts"},
+	enumlabel.Item[LowerStyle]{Code: LowerStyle_Skirt, Zh: "裙子", En: "skirt"},
+)
+
+// LowerStyleItems 获取下衣款式全部枚举项
+func LowerStyleItems() []enumlabel.Item[LowerStyle] {
+	return lowerStyleTable.Items()
+}
+
+// String 获取中文标签
+func (v LowerStyle) String() string {
+	return lowerStyleTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v LowerStyle) Label(lang enumlabel.Lang) string {
+	return lowerStyleTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v LowerStyle) Valid() bool {
+	return lowerStyleTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v LowerStyle) Validate() error {
+	return lowerStyleTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v LowerStyle) Labeled() enumlabel.Value {
+	return lowerStyleTable.Value(v)
+}
+
+// MarshalJSON 序列化为同时包含枚举值与中英文标签的JSON对象
+func (v LowerStyle) MarshalJSON() ([]byte, error) {
+	return lowerStyleTable.Marshal(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *LowerStyle) UnmarshalJSON(data []byte) error {
+	code, err := lowerStyleTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
+
+// BodyType 体型
+type BodyType int64
+
+// 体型枚举
+const (
+	BodyType_Unknown  BodyType = 0 // 未识别
+	BodyType_Standard BodyType = 1 // 标准
+	BodyType_Fat      BodyType = 2 // 胖
+	BodyType_Thin     BodyType = 3 // 瘦
+)
+
+// 体型标签表
+var bodyTypeTable = enumlabel.NewTable("bodyType",
+	enumlabel.Item[BodyType]{Code: BodyType_Unknown, Zh: "未识别", En: "unknown"},
+	enumlabel.Item[BodyType]{Code: BodyType_Standard, Zh: "标准", En: "standard"},
+	enumlabel.Item[BodyType]{Code: BodyType_Fat, Zh: "胖", En: "fat"},
+	enumlabel.Item[BodyType]{Code: BodyType_Thin, Zh: "瘦", En: "thin"},
+)
+
+// BodyTypeItems 获取体型全部枚举项
+func BodyTypeItems() []enumlabel.Item[BodyType] {
+	return bodyTypeTable.Items()
+}
+
+// String 获取中文标签
+func (v BodyType) String() string {
+	return bodyTypeTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v BodyType) Label(lang enumlabel.Lang) string {
+	return bodyTypeTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v BodyType) Valid() bool {
+	return bodyTypeTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v BodyType) Validate() error {
+	return bodyTypeTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v BodyType) Labeled() enumlabel.Value {
+	return bodyTypeTable.Value(v)
+}
+
+// MarshalJSON 序列化为同时包含枚举值与中英文标签的JSON对象
+func (v BodyType) MarshalJSON() ([]byte, error) {
+	return bodyTypeTable.Marshal(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *BodyType) UnmarshalJSON(data []byte) error {
+	code, err := bodyTypeTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
+
+// Backpack 背包
+type Backpack int64
+
+// 背包枚举
+const (
+	Backpack_Unknown Backpack = 0 // 未识别
+	Backpack_None    Backpack = 1 // 未背包
+	Backpack_Has     Backpack = 2 // 背包
+)
+
+// 背包标签表
+var backpackTable = enumlabel.NewTable("backpack",
+	enumlabel.Item[Backpack]{Code: Backpack_Unknown, Zh: "未识别", En: "unknown"},
+	enumlabel.Item[Backpack]{Code: Backpack_None, Zh: "未背包", En: "no backpack"},
+	enumlabel.Item[Backpack]{Code: Backpack_Has, Zh: "背包", En: "backpack"},
+)
+
+// BackpackItems 获取背包全部枚举项
+func BackpackItems() []enumlabel.Item[Backpack] {
+	return backpackTable.Items()
+}
+
+// String 获取中文标签
+func (v Backpack) String() string {
+	return backpackTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v Backpack) Label(lang enumlabel.Lang) string {
+	return backpackTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v Backpack) Valid() bool {
+	return backpackTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v Backpack) Validate() error {
+	return backpackTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v Backpack) Labeled() enumlabel.Value {
+	return backpackTable.Value(v)
+}
+
+// MarshalJSON 序列化为同时包含枚举值与中英文标签的JSON对象
+func (v Backpack) MarshalJSON() ([]byte, error) {
+	return backpackTable.Marshal(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *Backpack) UnmarshalJSON(data []byte) error {
+	code, err := backpackTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
+
+// Mustache 胡子
+type Mustache int64
+
+// 胡子枚举
+const (
+	Mustache_Unknown Mustache = 0 // 未识别
+	Mustache_None    Mustache = 1 // 没有胡子
+	Mustache_Has     Mustache = 2 // 有胡子
+)
+
+// 胡子标签表
+var mustacheTable = enumlabel.NewTable("mustache",
+	enumlabel.Item[Mustache]{Code: Mustache_Unknown, Zh: "未识别", En: "unknown"},
+	enumlabel.Item[Mustache]{Code: Mustache_None, Zh: "没有胡子", En: "no mustache"},
+	enumlabel.Item[Mustache]{Code: Mustache_Has, Zh: "有胡子", En: "mustache"},
+)
+
+// MustacheItems 获取胡子全部枚举项
+func MustacheItems() []enumlabel.Item[Mustache] {
+	return mustacheTable.Items()
+}
+
+// String 获取中文标签
+func (v Mustache) String() string {
+	return mustacheTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v Mustache) Label(lang enumlabel.Lang) string {
+	return mustacheTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v Mustache) Valid() bool {
+	return mustacheTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v Mustache) Validate() error {
+	return mustacheTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v Mustache) Labeled() enumlabel.Value {
+	return mustacheTable.Value(v)
+}
+
+// MarshalJSON 序列化为同时包含枚举值与中英文标签的JSON对象
+func (v Mustache) MarshalJSON() ([]byte, error) {
+	return mustacheTable.Marshal(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *Mustache) UnmarshalJSON(data []byte) error {
+	code, err := mustacheTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
+
+// CarryBag 是否拎东西
+type CarryBag int64
+
+// 是否拎东西枚举
+const (
+	CarryBag_Unknown CarryBag = 0 // 未识别
+	CarryBag_None    CarryBag = 1 // 未拎东西
+	CarryBag_Has     CarryBag = 2 // 拎东西
+)
+
+// 是否拎东西标签表
+var carryBagTable = enumlabel.NewTable("carryBag",
+	enumlabel.Item[CarryBag]{Code: CarryBag_Unknown, Zh: "未识别", En: "unknown"},
+	enumlabel.Item[CarryBag]{Code: CarryBag_None, Zh: "未拎东西", En: "not carrying"},
+	enumlabel.Item[CarryBag]{Code: CarryBag_Has, Zh: "拎东西", En: "carrying"},
+)
+
+// CarryBagItems 获取是否拎东西全部枚举项
+func CarryBagItems() []enumlabel.Item[CarryBag] {
+	return carryBagTable.Items()
+}
+
+// String 获取中文标签
+func (v CarryBag) String() string {
+	return carryBagTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v CarryBag) Label(lang enumlabel.Lang) string {
+	return carryBagTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v CarryBag) Valid() bool {
+	return carryBagTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v CarryBag) Validate() error {
+	return carryBagTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v CarryBag) Labeled() enumlabel.Value {
+	return carryBagTable.Value(v)
+}
+
+// MarshalJSON 序列化为同时包含枚举值与中英文标签的JSON对象
+func (v CarryBag) MarshalJSON() ([]byte, error) {
+	return carryBagTable.Marshal(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *CarryBag) UnmarshalJSON(data []byte) error {
+	code, err := carryBagTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
+
+// Satchel 斜挎包
+type Satchel int64
+
+// 斜挎包枚举
+const (
+	Satchel_Unknown Satchel = 0 // 未识别
+	Satchel_None    Satchel = 1 // 无斜挎包
+	Satchel_Has     Satchel = 2 // 有斜挎包
+)
+
+// 斜挎包标签表
+var satchelTable = enumlabel.NewTable("satchel",
+	enumlabel.Item[Satchel]{Code: Satchel_Unknown, Zh: "未识别", En: "unknown"},
+	enumlabel.Item[Satchel]{Code: Satchel_None, Zh: "无斜挎包", En: "no satchel"},
+	enumlabel.Item[Satchel]{Code: Satchel_Has, Zh: "有斜挎包", En: "satchel"},
+)
+
+// SatchelItems 获取斜挎包全部枚举项
+func SatchelItems() []enumlabel.Item[Satchel] {
+	return satchelTable.Items()
+}
+
+// String 获取中文标签
+func (v Satchel) String() string {
+	return satchelTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v Satchel) Label(lang enumlabel.Lang) string {
+	return satchelTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v Satchel) Valid() bool {
+	return satchelTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v Satchel) Validate() error {
+	return satchelTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v Satchel) Labeled() enumlabel.Value {
+	return satchelTable.Value(v)
+}
+
+// MarshalJSON 序列化为同时包含枚举值与中英文标签的JSON对象
+func (v Satchel) MarshalJSON() ([]byte, error) {
+	return satchelTable.Marshal(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *Satchel) UnmarshalJSON(data []byte) error {
+	code, err := satchelTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
+
+// FrontBag 前面背包
+type FrontBag int64
+
+// 前面背包枚举
+const (
+	FrontBag_Unknown FrontBag = 0 // 未识别
+	FrontBag_None    FrontBag = 1 // 无前背包
+	FrontBag_Has     FrontBag = 2 // 有前背包
+)
+
+// 前面背包标签表
+var frontBagTable = enumlabel.NewTable("frontBag",
+	enumlabel.Item[FrontBag]{Code: FrontBag_Unknown, Zh: "未识别", En: "unknown"},
+	enumlabel.Item[FrontBag]{Code: FrontBag_None, Zh: "无前背包", En: "no front bag"},
+	enumlabel.Item[FrontBag]{Code: FrontBag_Has, Zh: "有前背包", En: "front bag"},
+)
+
+// FrontBagItems 获取前面背包全部枚举项
+func FrontBagItems() []enumlabel.Item[FrontBag] {
+	return frontBagTable.Items()
+}
+
+// String 获取中文标签
+func (v FrontBag) String() string {
+	return frontBagTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v FrontBag) Label(lang enumlabel.Lang) string {
+	return frontBagTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v FrontBag) Valid() bool {
+	return frontBagTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v FrontBag) Validate() error {
+	return frontBagTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v FrontBag) Labeled() enumlabel.Value {
+	return frontBagTable.Value(v)
+}
+
+// MarshalJSON 序列化为同时包含枚举值与中英文标签的JSON对象
+func (v FrontBag) MarshalJSON() ([]byte, error) {
+	return frontBagTable.Marshal(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *FrontBag) UnmarshalJSON(data []byte) error {
+	code, err := frontBagTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
+
+// Umbrella 雨伞
+type Umbrella int64
+
+// 雨伞枚举
+const (
+	Umbrella_Unknown Umbrella = 0 // 未识别
+	Umbrella_None    Umbrella = 1 // 无雨伞
+	Umbrella_Has     Umbrella = 2 // 有雨伞
+)
+
+// 雨伞标签表
+var umbrellaTable = enumlabel.NewTable("umbrella",
+	enumlabel.Item[Umbrella]{Code: Umbrella_Unknown, Zh: "未识别", En: "unknown"},
+	enumlabel.Item[Umbrella]{Code: Umbrella_None, Zh: "无雨伞", En: "no umbrella"},
+	enumlabel.Item[Umbrella]{Code: Umbrella_Has, Zh: "有雨伞", En: "umbrella"},
+)
+
+// UmbrellaItems 获取雨伞全部枚举项
+func UmbrellaItems() []enumlabel.Item[Umbrella] {
+	return umbrellaTable.Items()
+}
+
+// String 获取中文标签
+func (v Umbrella) String() string {
+	return umbrellaTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v Umbrella) Label(lang enumlabel.Lang) string {
+	return umbrellaTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v Umbrella) Valid() bool {
+	return umbrellaTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v Umbrella) Validate() error {
+	return umbrellaTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v Umbrella) Labeled() enumlabel.Value {
+	return umbrellaTable.Value(v)
+}
+
+// MarshalJSON 序列化为同时包含枚举值与中英文标签的JSON对象
+func (v Umbrella) MarshalJSON() ([]byte, error) {
+	return umbrellaTable.Marshal(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *Umbrella) UnmarshalJSON(data []byte) error {
+	code, err := umbrellaTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
+
+// Luggage 行李箱
+type Luggage int64
+
+// 行李箱枚举
+const (
+	Luggage_Unknown Luggage = 0 // 未识别
+	Luggage_None    Luggage = 1 // 无行李箱
+	Luggage_Has     Luggage = 2 // 有行李箱
+)
+
+// 行李箱标签表
+var luggageTable = enumlabel.NewTable("luggage",
+	enumlabel.Item[Luggage]{Code: Luggage_Unknown, Zh: "未识别", En: "unknown"},
+	enumlabel.Item[Luggage]{Code: Luggage_None, Zh: "无行李箱", En: "no luggage"},
+	enumlabel.Item[Luggage]{Code: Luggage_Has, Zh: "有行李箱", En: "luggage"},
+)
+
+// LuggageItems 获取行李箱全部枚举项
+func LuggageItems() []enumlabel.Item[Luggage] {
+	return luggageTable.Items()
+}
+
+// String 获取中文标签
+func (v Luggage) String() string {
+	return luggageTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v Luggage) Label(lang enumlabel.Lang) string {
+	return luggageTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v Luggage) Valid() bool {
+	return luggageTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v Luggage) Validate() error {
+	return luggageTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v Luggage) Labeled() enumlabel.Value {
+	return luggageTable.Value(v)
+}
+
+// MarshalJSON 序列化为同时包含枚举值与中英文标签的JSON对象
+func (v Luggage) MarshalJSON() ([]byte, error) {
+	return luggageTable.Marshal(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *Luggage) UnmarshalJSON(data []byte) error {
+	code, err := luggageTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
+
+// MoveDirection 行进方向
+type MoveDirection int64
+
+// 行进方向枚举
+const (
+	MoveDirection_Unknown  MoveDirection = 0 // 未识别
+	MoveDirection_Forward  MoveDirection = 1 // 朝前
+	MoveDirection_Backward MoveDirection = 2 // 朝后
+)
+
+// 行进方向标签表
+var moveDirectionTable = enumlabel.NewTable("moveDirection",
+	enumlabel.Item[MoveDirection]{Code: MoveDirection_Unknown, Zh: "未识别", En: "unknown"},
+	enumlabel.Item[MoveDirection]{Code: MoveDirection_Forward, Zh: "朝前", En: "forward"},
+	enumlabel.Item[MoveDirection]{Code: MoveDirection_Backward, Zh: "朝后", En: "backward"},
+)
+
+// MoveDirectionItems 获取行进方向全部枚举项
+func MoveDirectionItems() []enumlabel.Item[MoveDirection] {
+	return moveDirectionTable.Items()
+}
+
+// String 获取中文标签
+func (v MoveDirection) String() string {
+	return moveDirectionTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v MoveDirection) Label(lang enumlabel.Lang) string {
+	return moveDirectionTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v MoveDirection) Valid() bool {
+	return moveDirectionTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v MoveDirection) Validate() error {
+	return moveDirectionTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v MoveDirection) Labeled() enumlabel.Value {
+	return moveDirectionTable.Value(v)
+}
+
+// MarshalJSON 序列化为同时包含枚举值与中英文标签的JSON对象
+func (v MoveDirection) MarshalJSON() ([]byte, error) {
+	return moveDirectionTable.Marshal(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *MoveDirection) UnmarshalJSON(data []byte) error {
+	code, err := moveDirectionTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
+
+// MoveSpeed 行进速度
+type MoveSpeed int64
+
+// 行进速度枚举
+const (
+	MoveSpeed_Unknown MoveSpeed = 0 // 未识别
+	MoveSpeed_Slow    MoveSpeed = 1 // 慢速
+	MoveSpeed_Fast    MoveSpeed = 2 // 快速
+)
+
+// 行进速度标签表
+var moveSpeedTable = enumlabel.NewTable("moveSpeed",
+	enumlabel.Item[MoveSpeed]{Code: MoveSpeed_Unknown, Zh: "未识别", En: "unknown"},
+	enumlabel.Item[MoveSpeed]{Code: MoveSpeed_Slow, Zh: "慢速", En: "slow"},
+	enumlabel.Item[MoveSpeed]{Code: MoveSpeed_Fast, Zh: "快速", En: "fast"},
+)
+
+// MoveSpeedItems 获取行进速度全部枚举项
+func MoveSpeedItems() []enumlabel.Item[MoveSpeed] {
+	return moveSpeedTable.Items()
+}
+
+// String 获取中文标签
+func (v MoveSpeed) String() string {
+	return moveSpeedTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v MoveSpeed) Label(lang enumlabel.Lang) string {
+	return moveSpeedTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v MoveSpeed) Valid() bool {
+	return moveSpeedTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v MoveSpeed) Validate() error {
+	return moveSpeedTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v MoveSpeed) Labeled() enumlabel.Value {
+	return moveSpeedTable.Value(v)
+}
+
+// MarshalJSON 序列化为同时包含枚举值与中英文标签的JSON对象
+func (v MoveSpeed) MarshalJSON() ([]byte, error) {
+	return moveSpeedTable.Marshal(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *MoveSpeed) UnmarshalJSON(data []byte) error {
+	code, err := moveSpeedTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
+
+// HumanView 朝向
+type HumanView int64
+
+// 朝向枚举
+const (
+	HumanView_Unknown HumanView = 0 // 未识别
+	HumanView_Front   HumanView = 1 // 朝前
+	HumanView_Back    HumanView = 2 // 朝后
+	HumanView_Left    HumanView = 3 // 朝左
+	HumanView_Right   HumanView = 4 // 朝右
+)
+
+// 朝向标签表
+var humanViewTable = enumlabel.NewTable("humanView",
+	enumlabel.Item[HumanView]{Code: HumanView_Unknown, Zh: "未识别", En: "unknown"},
+	enumlabel.Item[HumanView]{Code: HumanView_Front, Zh: "朝前", En: "front"},
+	enumlabel.Item[HumanView]{Code: HumanView_Back, Zh: "朝后", En: "back"},
+	enumlabel.Item[HumanView]{Code: HumanView_Left, Zh: "朝左", En: "left"},
+	enumlabel.Item[HumanView]{Code: HumanView_Right, Zh: "朝右", En: "right"},
+)
+
+// HumanViewItems 获取朝向全部枚举项
+func HumanViewItems() []enumlabel.Item[HumanView] {
+	return humanViewTable.Items()
+}
+
+// String 获取中文标签
+func (v HumanView) String() string {
+	return humanViewTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v HumanView) Label(lang enumlabel.Lang) string {
+	return humanViewTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v HumanView) Valid() bool {
+	return humanViewTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v HumanView) Validate() error {
+	return humanViewTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v HumanView) Labeled() enumlabel.Value {
+	return humanViewTable.Value(v)
+}
+
+// MarshalJSON 序列化为同时包含枚举值与中英文标签的JSON对象
+func (v HumanView) MarshalJSON() ([]byte, error) {
+	return humanViewTable.Marshal(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *HumanView) UnmarshalJSON(data []byte) error {
+	code, err := humanViewTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
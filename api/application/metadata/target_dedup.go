@@ -0,0 +1,94 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口智能元数据去重状态存储
+ */
+package metadata
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// DedupStore 去重状态存储接口
+//
+// 可基于Redis等外部存储实现，以便多个接收端实例共享去重状态
+type DedupStore interface {
+	// 记录事件标识
+	//
+	//	@param key: 事件标识
+	//	@param now: 当前时间
+	//	@param window: 去重时间窗口
+	//	@return 标识是否已在时间窗口内出现过
+	//	@return 异常信息
+	SeenOrAdd(key string, now time.Time, window time.Duration) (bool, error)
+}
+
+// 内存去重记录
+type memoryDedupEntry struct {
+	key      string    // 事件标识
+	expireAt time.Time // 过期时间
+}
+
+// MemoryDedupStore 内存去重状态存储
+//
+// 记录按写入顺序淘汰：过期记录在写入时清理，超出最大记录数时淘汰最早的记录
+type MemoryDedupStore struct {
+	mtx        sync.Mutex               // 互斥锁
+	maxEntries int                      // 最大记录数
+	order      *list.List               // 写入顺序
+	entries    map[string]*list.Element // 记录索引
+}
+
+// NewMemoryDedupStore 创建内存去重状态存储
+//
+//	@param maxEntries: 最大记录数（小于等于0时默认为100000）
+//	@return 内存去重状态存储
+func NewMemoryDedupStore(maxEntries int) *MemoryDedupStore {
+	if maxEntries <= 0 {
+		maxEntries = 100000
+	}
+	return &MemoryDedupStore{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// SeenOrAdd 记录事件标识
+func (s *MemoryDedupStore) SeenOrAdd(key string, now time.Time, window time.Duration) (bool, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	// 清理过期记录（写入顺序与过期顺序一致）
+	for elem := s.order.Front(); elem != nil; elem = s.order.Front() {
+		entry := elem.Value.(*memoryDedupEntry)
+		if entry.expireAt.After(now) {
+			break
+		}
+		s.order.Remove(elem)
+		delete(s.entries, entry.key)
+	}
+
+	// 时间窗口内已出现过
+	if _, ok := s.entries[key]; ok {
+		return true, nil
+	}
+
+	// 超出最大记录数时淘汰最早的记录
+	for s.order.Len() >= s.maxEntries {
+		elem := s.order.Front()
+		s.order.Remove(elem)
+		delete(s.entries, elem.Value.(*memoryDedupEntry).key)
+	}
+
+	// 写入记录
+	s.entries[key] = s.order.PushBack(&memoryDedupEntry{
+		key:      key,
+		expireAt: now.Add(window),
+	})
+
+	// OK
+	return false, nil
+}
@@ -0,0 +1,135 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口智能元数据目标事件
+ */
+package metadata
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TargetEvent 元数据目标事件（单个目标）
+type TargetEvent struct {
+	Common     SubscribeUploadCommonInfo       // 元数据通用信息
+	Target     SubscribeTargetUploadDetailInfo // 目标详细信息
+	ShotTime   time.Time                       // 抓拍时间（UTC，无法解析时为零值）
+	ReceivedAt time.Time                       // 接收时间（本机时间）
+}
+
+// ParseTargetID 解析目标ID
+//
+//	@param id: 目标ID（FaceID）或图像ID（ImageID），编码格式：设备编码+抓拍时间YYMMDDhhmmssSSS+目标ID（HEX编码）
+//	@param deviceID: 设备编码
+//	@return 目标ID
+//	@return 异常信息
+func ParseTargetID(id, deviceID string) (int64, error) {
+	// 去除设备编码
+	if deviceID == "" || !strings.HasPrefix(id, deviceID) {
+		return 0, errors.New("invalid target id: " + id)
+	}
+	rest := id[len(deviceID):]
+	// 去除抓拍时间
+	if len(rest) <= 15 {
+		return 0, errors.New("invalid target id: " + id)
+	}
+	if _, err := ParseShotTime(rest[:15]); err != nil {
+		return 0, errors.New("invalid target id: " + id)
+	}
+	// 解析目标ID（INT64的HEX编码）
+	val, err := strconv.ParseUint(rest[15:], 16, 64)
+	if err != nil {
+		return 0, errors.New("invalid target id: " + id)
+	}
+	// OK
+	return int64(val), nil
+}
+
+// TargetID 获取目标ID
+//
+// 优先解析目标的FaceID，无法解析时依次解析各图像的图像ID
+//
+//	@return 目标ID
+//	@return 是否解析成功
+func (e *TargetEvent) TargetID() (int64, bool) {
+	if id, err := ParseTargetID(e.Target.FaceID, e.Common.DeviceID); err == nil {
+		return id, true
+	}
+	for _, img := range e.Target.SubImageList {
+		if id, err := ParseTargetID(img.ImageID, e.Common.DeviceID); err == nil {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// Key 事件唯一标识（设备编码 + 目标ID）
+//
+// 无法解析目标ID时返回空字符串，此时事件不参与去重
+func (e *TargetEvent) Key() string {
+	id, ok := e.TargetID()
+	if !ok {
+		return ""
+	}
+	return e.Common.DeviceID + "/" + strconv.FormatInt(id, 16)
+}
+
+// 排序时间（抓拍时间无法解析时使用接收时间）
+func (e *TargetEvent) sortTime() time.Time {
+	if e.ShotTime.IsZero() {
+		return e.ReceivedAt
+	}
+	return e.ShotTime
+}
+
+// ParseShotTime 解析抓拍时间
+//
+//	@param val: 抓拍时间（UTC），格式：YYMMDDhhmmssSSS
+//	@return 抓拍时间
+//	@return 异常信息
+func ParseShotTime(val string) (time.Time, error) {
+	// 检查长度
+	if len(val) != 15 {
+		return time.Time{}, errors.New("invalid shot time: " + val)
+	}
+	// 解析到秒
+	t, err := time.ParseInLocation("060102150405", val[:12], time.UTC)
+	if err != nil {
+		return time.Time{}, err
+	}
+	// 解析毫秒
+	ms, err := strconv.Atoi(val[12:])
+	if err != nil {
+		return time.Time{}, errors.New("invalid shot time: " + val)
+	}
+	// OK
+	return t.Add(time.Duration(ms) * time.Millisecond), nil
+}
+
+// SplitTargetEvents 将元数据上报参数拆分为单个目标事件
+//
+//	@param params: 元数据订阅目标数据上报参数
+//	@param receivedAt: 接收时间
+//	@return 目标事件列表
+func SplitTargetEvents(params *SubscribeTargetUploadParams, receivedAt time.Time) []*TargetEvent {
+	events := make([]*TargetEvent, 0, len(params.Metadata.TargetList))
+	for _, target := range params.Metadata.TargetList {
+		event := &TargetEvent{
+			Common:     params.Metadata.Common,
+			Target:     target,
+			ReceivedAt: receivedAt,
+		}
+		// 使用第一张可解析的图像抓拍时间
+		for _, img := range target.SubImageList {
+			if t, err := ParseShotTime(img.ShotTime); err == nil {
+				event.ShotTime = t
+				break
+			}
+		}
+		events = append(events, event)
+	}
+	return events
+}
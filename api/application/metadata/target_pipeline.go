@@ -0,0 +1,256 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口智能元数据目标事件处理管道（去重与排序）
+ */
+package metadata
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// TargetPipelineOptions 目标事件处理管道配置
+type TargetPipelineOptions struct {
+	// 去重时间窗口
+	//
+	//	同一设备同一目标ID的事件在该时间窗口内只交付一次，0表示不去重
+	DedupWindow time.Duration
+	// 去重状态存储（选填，默认使用内存存储）
+	DedupStore DedupStore
+	// 排序时间窗口
+	//
+	//	事件自接收起最多缓冲该时长，期间按抓拍时间排序后交付，0表示不排序；
+	//	某事件到期时，抓拍时间不晚于该事件的缓冲事件一并交付，以免时钟滞后的设备阻塞其他设备的事件
+	ReorderWindow time.Duration
+	// 排序缓冲最大事件数（选填，默认10000）
+	//
+	//	超出时立即交付抓拍时间最早的事件
+	ReorderMaxEvents int
+	// 异常回调（选填，如去重状态存储异常）
+	//
+	//	去重状态存储异常时事件仍会交付，以免丢失数据
+	OnError func(err error)
+}
+
+// TargetPipeline 目标事件处理管道
+//
+// SDC在接收端响应缓慢时会重传元数据，同一目标可能多次、乱序到达。管道对上报的元数据拆分为单个目标事件，
+// 按设备编码与目标ID去重，并可在较小的时间窗口内按抓拍时间排序后交付给消费者。
+type TargetPipeline struct {
+	opts     TargetPipelineOptions    // 配置
+	consumer func(event *TargetEvent) // 事件消费者
+
+	mtx     sync.Mutex         // 互斥锁
+	buffer  targetEventHeap    // 排序缓冲（按抓拍时间排序）
+	arrival []*targetEventItem // 排序缓冲（按推送顺序排列，用于判断到期）
+	wakeup  chan struct{}      // 排序协程唤醒信号
+	closed  bool               // 是否已关闭
+	done    chan struct{}      // 排序协程结束信号
+	deliver sync.Mutex         // 交付互斥锁（保证消费者串行调用）
+}
+
+// NewTargetPipeline 创建目标事件处理管道
+//
+// 未启用排序时，消费者在Push的调用协程中同步执行；启用排序时，消费者在管道内部协程中执行。
+// 消费者总是被串行调用。
+//
+//	@param consumer: 事件消费者
+//	@param opts: 管道配置
+//	@return 目标事件处理管道
+func NewTargetPipeline(consumer func(event *TargetEvent), opts TargetPipelineOptions) *TargetPipeline {
+	// 默认配置
+	if opts.DedupWindow > 0 && opts.DedupStore == nil {
+		opts.DedupStore = NewMemoryDedupStore(0)
+	}
+	if opts.ReorderMaxEvents <= 0 {
+		opts.ReorderMaxEvents = 10000
+	}
+	// 创建管道
+	p := &TargetPipeline{
+		opts:     opts,
+		consumer: consumer,
+		wakeup:   make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	// 启动排序协程
+	if opts.ReorderWindow > 0 {
+		go p.runReorder()
+	} else {
+		close(p.done)
+	}
+	// OK
+	return p
+}
+
+// Push 推送元数据上报参数
+//
+//	@param params: 元数据订阅目标数据上报参数（通常来自SubscribeTargetUpload）
+func (p *TargetPipeline) Push(params *SubscribeTargetUploadParams) {
+	now := time.Now()
+	for _, event := range SplitTargetEvents(params, now) {
+		p.PushEvent(event)
+	}
+}
+
+// PushEvent 推送单个目标事件
+func (p *TargetPipeline) PushEvent(event *TargetEvent) {
+	// 去重（无法解析目标ID时不去重）
+	if key := event.Key(); p.opts.DedupWindow > 0 && key != "" {
+		seen, err := p.opts.DedupStore.SeenOrAdd(key, time.Now(), p.opts.DedupWindow)
+		if err != nil && p.opts.OnError != nil {
+			p.opts.OnError(err)
+		}
+		if err == nil && seen {
+			return
+		}
+	}
+
+	// 未启用排序时直接交付
+	if p.opts.ReorderWindow <= 0 {
+		p.emit(event)
+		return
+	}
+
+	// 写入排序缓冲
+	var overflow *TargetEvent
+	p.mtx.Lock()
+	if p.closed {
+		p.mtx.Unlock()
+		p.emit(event)
+		return
+	}
+	item := &targetEventItem{event: event}
+	heap.Push(&p.buffer, item)
+	p.arrival = append(p.arrival, item)
+	if p.buffer.Len() > p.opts.ReorderMaxEvents {
+		item := heap.Pop(&p.buffer).(*targetEventItem)
+		item.emitted = true
+		overflow = item.event
+	}
+	p.mtx.Unlock()
+
+	// 缓冲已满时立即交付最早的事件
+	if overflow != nil {
+		p.emit(overflow)
+	}
+	// 唤醒排序协程
+	select {
+	case p.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// Close 关闭管道，交付缓冲中剩余的全部事件
+func (p *TargetPipeline) Close() {
+	p.mtx.Lock()
+	if p.closed {
+		p.mtx.Unlock()
+		return
+	}
+	p.closed = true
+	p.mtx.Unlock()
+	// 唤醒排序协程并等待结束
+	select {
+	case p.wakeup <- struct{}{}:
+	default:
+	}
+	<-p.done
+}
+
+// 交付事件
+func (p *TargetPipeline) emit(event *TargetEvent) {
+	p.deliver.Lock()
+	defer p.deliver.Unlock()
+	p.consumer(event)
+}
+
+// 排序协程
+func (p *TargetPipeline) runReorder() {
+	defer close(p.done)
+	for {
+		// 取出可交付的事件
+		p.mtx.Lock()
+		var ready []*TargetEvent
+		var wait time.Duration
+		var cutoff time.Time
+		now := time.Now()
+		// 按推送顺序查找已到期的事件，确定本次交付的抓拍时间上限
+		for len(p.arrival) > 0 {
+			item := p.arrival[0]
+			if !item.emitted && !p.closed {
+				if remain := item.event.ReceivedAt.Add(p.opts.ReorderWindow).Sub(now); remain > 0 {
+					wait = remain
+					break
+				}
+				if t := item.event.sortTime(); t.After(cutoff) {
+					cutoff = t
+				}
+			}
+			p.arrival[0] = nil
+			p.arrival = p.arrival[1:]
+		}
+		// 交付抓拍时间不晚于上限的事件（已关闭时交付全部事件）
+		for p.buffer.Len() > 0 {
+			if !p.closed && p.buffer[0].event.sortTime().After(cutoff) {
+				break
+			}
+			item := heap.Pop(&p.buffer).(*targetEventItem)
+			item.emitted = true
+			ready = append(ready, item.event)
+		}
+		closed := p.closed
+		p.mtx.Unlock()
+
+		// 交付事件
+		for _, event := range ready {
+			p.emit(event)
+		}
+		if closed {
+			return
+		}
+
+		// 等待新事件或下一个事件到期
+		var timer <-chan time.Time
+		if wait > 0 {
+			timer = time.After(wait)
+		}
+		select {
+		case <-p.wakeup:
+		case <-timer:
+		}
+	}
+}
+
+// 排序缓冲中的目标事件
+type targetEventItem struct {
+	event   *TargetEvent // 目标事件
+	emitted bool         // 是否已交付
+}
+
+// 目标事件最小堆（按抓拍时间排序，抓拍时间无法解析时使用接收时间，时间相同时按接收时间排序）
+type targetEventHeap []*targetEventItem
+
+func (h targetEventHeap) Len() int { return len(h) }
+
+func (h targetEventHeap) Less(i, j int) bool {
+	ti, tj := h[i].event.sortTime(), h[j].event.sortTime()
+	if !ti.Equal(tj) {
+		return ti.Before(tj)
+	}
+	return h[i].event.ReceivedAt.Before(h[j].event.ReceivedAt)
+}
+
+func (h targetEventHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *targetEventHeap) Push(x any) { *h = append(*h, x.(*targetEventItem)) }
+
+func (h *targetEventHeap) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return item
+}
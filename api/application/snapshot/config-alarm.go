@@ -0,0 +1,116 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口告警联动抓拍配置
+ */
+package snapshot
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
+)
+
+// AlarmSnapConfig 告警联动抓拍配置
+type AlarmSnapConfig struct {
+	// 是否启用告警联动抓拍
+	Enable bool `json:"enable"`
+	// 每次告警抓拍张数
+	//
+	//	取值范围：[1,5]
+	SnapNum int `json:"snapNum"`
+	// 连续抓拍间隔（单位：毫秒）
+	//
+	//	取值范围：[0,10000]
+	SnapInterval int `json:"snapInterval"`
+	// 告警前抓拍时长（单位：秒，0表示不抓拍告警前图片）
+	PreSnapTime int `json:"preSnapTime"`
+	// 是否上传抓拍图片到平台
+	UploadEnable bool `json:"uploadEnable"`
+}
+
+// Validate 校验告警联动抓拍配置
+func (c *AlarmSnapConfig) Validate() error {
+	if c.Enable && (c.SnapNum < 1 || c.SnapNum > 5) {
+		return fmt.Errorf("invalid snap num: %d", c.SnapNum)
+	}
+	if c.SnapInterval < 0 || c.SnapInterval > 10000 {
+		return fmt.Errorf("invalid snap interval: %d", c.SnapInterval)
+	}
+	if c.PreSnapTime < 0 {
+		return fmt.Errorf("invalid pre snap time: %d", c.PreSnapTime)
+	}
+	return nil
+}
+
+// AlarmSnapConfigQuery 告警联动抓拍配置查询
+//
+//	@param uuid: 通道UUID
+//	@return 告警联动抓拍配置
+//	@return 异常信息
+func (p *Manager) AlarmSnapConfigQuery(uuid string) (*AlarmSnapConfig, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureSnapshotConfig); err != nil {
+		return nil, err
+	}
+
+	// 获取Socket连接的HTTP客户端
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply AlarmSnapConfig
+	_, err := client.Get("/SDCAPI/V1.0/Storage/Snapshot/AlarmConfig").
+		SetContentType("application/x-www-form-urlencoded").
+		SetQuery("UUID", uuid).
+		DecodeJSON(&reply)
+	if err != nil {
+		return nil, err
+	}
+
+	// OK
+	return &reply, nil
+}
+
+// AlarmSnapConfigSettingReply 告警联动抓拍配置设置响应
+type AlarmSnapConfigSettingReply = common.Response[common.ResponseStatus]
+
+// AlarmSnapConfigSetting 告警联动抓拍配置设置
+//
+//	@param uuid: 通道UUID
+//	@param params: 告警联动抓拍配置
+//	@return 异常信息
+func (p *Manager) AlarmSnapConfigSetting(uuid string, params AlarmSnapConfig) error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureSnapshotConfig); err != nil {
+		return err
+	}
+
+	// 校验参数
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	// 获取Socket连接的HTTP客户端
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply AlarmSnapConfigSettingReply
+	_, err := client.Put("/SDCAPI/V1.0/Storage/Snapshot/AlarmConfig").
+		SetQuery("UUID", uuid).
+		SetJSON(&params).
+		DecodeJSON(&reply)
+	if err != nil {
+		return err
+	}
+
+	// 检查状态码
+	if reply.ResponseStatus.StatusCode != 0 {
+		return errors.New(reply.ResponseStatus.StatusString)
+	}
+
+	// OK
+	return nil
+}
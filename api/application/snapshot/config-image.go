@@ -0,0 +1,124 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口抓拍图片质量配置
+ */
+package snapshot
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
+)
+
+// SnapImageQuality 抓拍图片质量
+type SnapImageQuality int
+
+const (
+	// 抓拍图片质量：低
+	SnapImageQuality_Low SnapImageQuality = 0
+	// 抓拍图片质量：中
+	SnapImageQuality_Medium SnapImageQuality = 1
+	// 抓拍图片质量：高
+	SnapImageQuality_High SnapImageQuality = 2
+)
+
+// SnapImageConfig 抓拍图片质量配置
+type SnapImageConfig struct {
+	// 图片宽度（单位：像素，0表示跟随码流分辨率）
+	Width int `json:"width"`
+	// 图片高度（单位：像素，0表示跟随码流分辨率）
+	Height int `json:"height"`
+	// 图片质量
+	Quality SnapImageQuality `json:"quality"`
+	// 图片大小上限（单位：KB，0表示不限制）
+	MaxSize int `json:"maxSize"`
+	// 是否叠加OSD
+	OsdEnable bool `json:"osdEnable"`
+}
+
+// Validate 校验抓拍图片质量配置
+func (c *SnapImageConfig) Validate() error {
+	if c.Width < 0 || c.Height < 0 || (c.Width == 0) != (c.Height == 0) {
+		return fmt.Errorf("invalid resolution: %dx%d", c.Width, c.Height)
+	}
+	if c.Quality < SnapImageQuality_Low || c.Quality > SnapImageQuality_High {
+		return fmt.Errorf("invalid quality: %d", c.Quality)
+	}
+	if c.MaxSize < 0 {
+		return fmt.Errorf("invalid max size: %d", c.MaxSize)
+	}
+	return nil
+}
+
+// SnapImageConfigQuery 抓拍图片质量配置查询
+//
+//	@param uuid: 通道UUID
+//	@return 抓拍图片质量配置
+//	@return 异常信息
+func (p *Manager) SnapImageConfigQuery(uuid string) (*SnapImageConfig, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureSnapshotConfig); err != nil {
+		return nil, err
+	}
+
+	// 获取Socket连接的HTTP客户端
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply SnapImageConfig
+	_, err := client.Get("/SDCAPI/V1.0/Storage/Snapshot/ImageConfig").
+		SetContentType("application/x-www-form-urlencoded").
+		SetQuery("UUID", uuid).
+		DecodeJSON(&reply)
+	if err != nil {
+		return nil, err
+	}
+
+	// OK
+	return &reply, nil
+}
+
+// SnapImageConfigSettingReply 抓拍图片质量配置设置响应
+type SnapImageConfigSettingReply = common.Response[common.ResponseStatus]
+
+// SnapImageConfigSetting 抓拍图片质量配置设置
+//
+//	@param uuid: 通道UUID
+//	@param params: 抓拍图片质量配置
+//	@return 异常信息
+func (p *Manager) SnapImageConfigSetting(uuid string, params SnapImageConfig) error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureSnapshotConfig); err != nil {
+		return err
+	}
+
+	// 校验参数
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	// 获取Socket连接的HTTP客户端
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply SnapImageConfigSettingReply
+	_, err := client.Put("/SDCAPI/V1.0/Storage/Snapshot/ImageConfig").
+		SetQuery("UUID", uuid).
+		SetJSON(&params).
+		DecodeJSON(&reply)
+	if err != nil {
+		return err
+	}
+
+	// 检查状态码
+	if reply.ResponseStatus.StatusCode != 0 {
+		return errors.New(reply.ResponseStatus.StatusString)
+	}
+
+	// OK
+	return nil
+}
@@ -0,0 +1,107 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口抓拍图片存储策略配置
+ */
+package snapshot
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
+)
+
+// SnapStorageConfig 抓拍图片存储策略配置
+type SnapStorageConfig struct {
+	// 存储空间满时是否覆盖最早的抓拍图片
+	OverwriteEnable bool `json:"overwriteEnable"`
+	// 抓拍图片保留天数（0表示不限制）
+	ReserveDays int `json:"reserveDays"`
+	// 抓拍图片占用存储空间比例（单位：百分比）
+	//
+	//	取值范围：[1,100]
+	QuotaPercent int `json:"quotaPercent"`
+}
+
+// Validate 校验抓拍图片存储策略配置
+func (c *SnapStorageConfig) Validate() error {
+	if c.ReserveDays < 0 {
+		return fmt.Errorf("invalid reserve days: %d", c.ReserveDays)
+	}
+	if c.QuotaPercent < 1 || c.QuotaPercent > 100 {
+		return fmt.Errorf("invalid quota percent: %d", c.QuotaPercent)
+	}
+	return nil
+}
+
+// SnapStorageConfigQuery 抓拍图片存储策略配置查询
+//
+//	@param uuid: 通道UUID
+//	@return 抓拍图片存储策略配置
+//	@return 异常信息
+func (p *Manager) SnapStorageConfigQuery(uuid string) (*SnapStorageConfig, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureSnapshotConfig); err != nil {
+		return nil, err
+	}
+
+	// 获取Socket连接的HTTP客户端
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply SnapStorageConfig
+	_, err := client.Get("/SDCAPI/V1.0/Storage/Snapshot/StorageConfig").
+		SetContentType("application/x-www-form-urlencoded").
+		SetQuery("UUID", uuid).
+		DecodeJSON(&reply)
+	if err != nil {
+		return nil, err
+	}
+
+	// OK
+	return &reply, nil
+}
+
+// SnapStorageConfigSettingReply 抓拍图片存储策略配置设置响应
+type SnapStorageConfigSettingReply = common.Response[common.ResponseStatus]
+
+// SnapStorageConfigSetting 抓拍图片存储策略配置设置
+//
+//	@param uuid: 通道UUID
+//	@param params: 抓拍图片存储策略配置
+//	@return 异常信息
+func (p *Manager) SnapStorageConfigSetting(uuid string, params SnapStorageConfig) error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureSnapshotConfig); err != nil {
+		return err
+	}
+
+	// 校验参数
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	// 获取Socket连接的HTTP客户端
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply SnapStorageConfigSettingReply
+	_, err := client.Put("/SDCAPI/V1.0/Storage/Snapshot/StorageConfig").
+		SetQuery("UUID", uuid).
+		SetJSON(&params).
+		DecodeJSON(&reply)
+	if err != nil {
+		return err
+	}
+
+	// 检查状态码
+	if reply.ResponseStatus.StatusCode != 0 {
+		return errors.New(reply.ResponseStatus.StatusString)
+	}
+
+	// OK
+	return nil
+}
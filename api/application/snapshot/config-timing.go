@@ -0,0 +1,129 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口定时抓拍配置
+ */
+package snapshot
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
+)
+
+// TimingSnapSection 定时抓拍时间段
+type TimingSnapSection struct {
+	// 星期（0-星期日，1~6-星期一至星期六）
+	WeekDay int `json:"weekDay"`
+	// 开始时间（格式：hh:mm:ss，设备本地时间）
+	StartTime string `json:"startTime"`
+	// 结束时间（格式：hh:mm:ss，设备本地时间）
+	EndTime string `json:"endTime"`
+}
+
+// TimingSnapConfig 定时抓拍配置
+type TimingSnapConfig struct {
+	// 是否启用定时抓拍
+	Enable bool `json:"enable"`
+	// 抓拍间隔（单位：秒）
+	//
+	//	取值范围：[1,86400]
+	Interval int `json:"interval"`
+	// 抓拍时间段（为空时全天抓拍）
+	Sections []TimingSnapSection `json:"timeSection"`
+}
+
+// 时间格式
+var timingSnapTimePattern = regexp.MustCompile(`^([01]\d|2[0-3]):[0-5]\d:[0-5]\d$|^24:00:00$`)
+
+// Validate 校验定时抓拍配置
+func (c *TimingSnapConfig) Validate() error {
+	if c.Enable && (c.Interval < 1 || c.Interval > 86400) {
+		return fmt.Errorf("invalid interval: %d", c.Interval)
+	}
+	for _, section := range c.Sections {
+		if section.WeekDay < 0 || section.WeekDay > 6 {
+			return fmt.Errorf("invalid week day: %d", section.WeekDay)
+		}
+		if !timingSnapTimePattern.MatchString(section.StartTime) || !timingSnapTimePattern.MatchString(section.EndTime) {
+			return fmt.Errorf("invalid time section: %s-%s", section.StartTime, section.EndTime)
+		}
+		if section.StartTime >= section.EndTime {
+			return fmt.Errorf("invalid time section: %s-%s", section.StartTime, section.EndTime)
+		}
+	}
+	return nil
+}
+
+// TimingSnapConfigQuery 定时抓拍配置查询
+//
+//	@param uuid: 通道UUID
+//	@return 定时抓拍配置
+//	@return 异常信息
+func (p *Manager) TimingSnapConfigQuery(uuid string) (*TimingSnapConfig, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureSnapshotConfig); err != nil {
+		return nil, err
+	}
+
+	// 获取Socket连接的HTTP客户端
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply TimingSnapConfig
+	_, err := client.Get("/SDCAPI/V1.0/Storage/Snapshot/TimingConfig").
+		SetContentType("application/x-www-form-urlencoded").
+		SetQuery("UUID", uuid).
+		DecodeJSON(&reply)
+	if err != nil {
+		return nil, err
+	}
+
+	// OK
+	return &reply, nil
+}
+
+// TimingSnapConfigSettingReply 定时抓拍配置设置响应
+type TimingSnapConfigSettingReply = common.Response[common.ResponseStatus]
+
+// TimingSnapConfigSetting 定时抓拍配置设置
+//
+//	@param uuid: 通道UUID
+//	@param params: 定时抓拍配置
+//	@return 异常信息
+func (p *Manager) TimingSnapConfigSetting(uuid string, params TimingSnapConfig) error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureSnapshotConfig); err != nil {
+		return err
+	}
+
+	// 校验参数
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	// 获取Socket连接的HTTP客户端
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 发送请求
+	var reply TimingSnapConfigSettingReply
+	_, err := client.Put("/SDCAPI/V1.0/Storage/Snapshot/TimingConfig").
+		SetQuery("UUID", uuid).
+		SetJSON(&params).
+		DecodeJSON(&reply)
+	if err != nil {
+		return err
+	}
+
+	// 检查状态码
+	if reply.ResponseStatus.StatusCode != 0 {
+		return errors.New(reply.ResponseStatus.StatusString)
+	}
+
+	// OK
+	return nil
+}
@@ -0,0 +1,255 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口抓拍图片下载
+ */
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
+	"github.com/kaicen-x/holosens-sdc-sdk/pkg/httpconn"
+)
+
+var (
+	// ErrContentSizeMismatch：下载的抓拍图大小与查询结果不一致
+	ErrContentSizeMismatch = errors.New("snapshot content size mismatch")
+	// ErrNoImageContent：下载响应中没有图片内容
+	ErrNoImageContent = errors.New("no image content")
+)
+
+// DownloadSessionCreateParams 抓拍图片下载会话创建参数
+type DownloadSessionCreateParams struct {
+	UUID string `json:"UUID"` // 通道UUID
+}
+
+// DownloadSessionCreateReplyData 抓拍图片下载会话创建响应数据
+type DownloadSessionCreateReplyData struct {
+	// 通用响应状态
+	common.ResponseStatus
+	// 下载会话ID
+	SessionID string `json:"sessionId"`
+}
+
+// DownloadSessionCreateReply 抓拍图片下载会话创建响应
+type DownloadSessionCreateReply = common.Response[DownloadSessionCreateReplyData]
+
+// DownloadSessionCloseReply 抓拍图片下载会话结束响应
+type DownloadSessionCloseReply = common.Response[common.ResponseStatus]
+
+// ImageDownloadParams 抓拍图片下载参数
+type ImageDownloadParams struct {
+	// 通道UUID（必填）
+	UUID string
+	// 抓拍图名称（必填，来自抓拍图查询结果）
+	ContentId string
+	// 抓拍图大小（选填，来自抓拍图查询结果，单位：字节）
+	//
+	//	非0时校验下载的数据大小是否一致
+	ContentSize uint32
+}
+
+// ImageDownloadReply 抓拍图片下载响应
+type ImageDownloadReply struct {
+	ContentType string          // 抓拍图片格式
+	ContentSize int64           // 抓拍图片大小（实际写入的字节数）
+	FileName    string          // 抓拍图片文件名
+	FileTime    time.Time       // 文件修改时间（响应头Last-Modified，并非抓拍时间，抓拍时间请使用查询结果；设备未返回时为零值）
+	Metadata    json.RawMessage // 设备随图片返回的JSON元数据（未返回时为空）
+}
+
+// 创建下载会话（调用前需持有连接锁）
+func downloadSessionCreate(client *httpconn.HttpClient, uuid string) (string, error) {
+	var reply DownloadSessionCreateReply
+	_, err := client.Post("/SDCAPI/V1.0/Storage/Snapshot/Download/Session").
+		SetJSON(&DownloadSessionCreateParams{UUID: uuid}).
+		DecodeJSON(&reply)
+	if err != nil {
+		return "", err
+	}
+	if reply.ResponseStatus.StatusCode != 0 {
+		return "", errors.New(reply.ResponseStatus.StatusString)
+	}
+	return reply.ResponseStatus.SessionID, nil
+}
+
+// 结束下载会话（调用前需持有连接锁）
+func downloadSessionClose(client *httpconn.HttpClient, sessionID string) error {
+	var reply DownloadSessionCloseReply
+	_, err := client.Delete("/SDCAPI/V1.0/Storage/Snapshot/Download/Session").
+		SetContentType("application/x-www-form-urlencoded").
+		SetQuery("SessionId", sessionID).
+		DecodeJSON(&reply)
+	if err != nil {
+		return err
+	}
+	if reply.ResponseStatus.StatusCode != 0 {
+		return errors.New(reply.ResponseStatus.StatusString)
+	}
+	return nil
+}
+
+// 从响应头解析文件修改时间
+func parseFileTimeHeader(header http.Header) time.Time {
+	if val := header.Get("Last-Modified"); val != "" {
+		if t, err := http.ParseTime(val); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// 写入图片数据并统计大小
+func copyImage(w io.Writer, r io.Reader, reply *ImageDownloadReply) error {
+	n, err := io.Copy(w, r)
+	reply.ContentSize += n
+	return err
+}
+
+// 读取下载响应（支持图片直出与multipart两种响应格式）
+func readImageDownload(res *http.Response, w io.Writer) (*ImageDownloadReply, error) {
+	// 检查响应状态码
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+		if len(body) > 0 {
+			return nil, errors.New(string(body))
+		}
+		return nil, errors.New(res.Status)
+	}
+	reply := &ImageDownloadReply{
+		ContentType: res.Header.Get("Content-Type"),
+		FileTime:    parseFileTimeHeader(res.Header),
+	}
+	mediatype, params, err := mime.ParseMediaType(reply.ContentType)
+	if err == nil {
+		// 文件名
+		if _, dispParams, err := mime.ParseMediaType(res.Header.Get("Content-Disposition")); err == nil {
+			reply.FileName = dispParams["filename"]
+		}
+	}
+
+	// 图片直出
+	if err != nil || !strings.HasPrefix(mediatype, "multipart/") {
+		if strings.HasPrefix(mediatype, "application/json") {
+			// 设备以JSON返回错误信息
+			body, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+			var status common.Response[common.ResponseStatus]
+			if json.Unmarshal(body, &status) == nil && status.ResponseStatus.StatusCode != 0 {
+				return nil, errors.New(status.ResponseStatus.StatusString)
+			}
+			return nil, ErrNoImageContent
+		}
+		if err := copyImage(w, res.Body, reply); err != nil {
+			return nil, err
+		}
+		return reply, nil
+	}
+
+	// multipart响应：图片写入输出，JSON作为元数据
+	boundary, ok := params["boundary"]
+	if !ok {
+		return nil, errors.New("invalid boundary")
+	}
+	reader := multipart.NewReader(res.Body, boundary)
+	found := false
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		partType := part.Header.Get("Content-Type")
+		switch {
+		case strings.Contains(partType, "json"):
+			data, err := io.ReadAll(part)
+			if err != nil {
+				return nil, err
+			}
+			reply.Metadata = data
+		case !found && strings.HasPrefix(partType, "image/"):
+			found = true
+			reply.ContentType = partType
+			reply.FileName = part.FileName()
+			if err := copyImage(w, part, reply); err != nil {
+				return nil, err
+			}
+		}
+		part.Close()
+	}
+	if !found {
+		return nil, ErrNoImageContent
+	}
+	return reply, nil
+}
+
+// ImageDownload 抓拍图片下载
+//
+// 依次执行下载会话创建、下载开始、下载结束，抓拍图片以流的方式写入输出，不会整体缓存在内存中。
+//
+//	@param params: 抓拍图片下载参数
+//	@param w: 图片输出
+//	@return 抓拍图片下载响应
+//	@return 异常信息（数据大小与查询结果不一致时返回ErrContentSizeMismatch，下载会话结束失败时返回该异常，两种情况下图片数据均已写入输出，同时返回下载响应）
+func (p *Manager) ImageDownload(params ImageDownloadParams, w io.Writer) (*ImageDownloadReply, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureSnapshot); err != nil {
+		return nil, err
+	}
+
+	// 获取Socket连接的HTTP客户端
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 创建下载会话
+	sessionID, err := downloadSessionCreate(client, params.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 开始下载
+	reply, err := func() (*ImageDownloadReply, error) {
+		res, err := client.Get("/SDCAPI/V1.0/Storage/Snapshot/Download").
+			SetContentType("application/x-www-form-urlencoded").
+			SetQuery("SessionId", sessionID).
+			SetQuery("UUID", params.UUID).
+			SetQuery("ContentId", params.ContentId).
+			Send()
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			// 读完剩余数据，避免残留数据影响同一连接上的后续请求
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}()
+		return readImageDownload(res, w)
+	}()
+
+	// 结束下载会话
+	closeErr := downloadSessionClose(client, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if closeErr != nil {
+		return reply, closeErr
+	}
+
+	// 校验数据大小
+	if params.ContentSize > 0 && reply.ContentSize != int64(params.ContentSize) {
+		return reply, fmt.Errorf("%w: expected %d, got %d", ErrContentSizeMismatch, params.ContentSize, reply.ContentSize)
+	}
+
+	// OK
+	return reply, nil
+}
@@ -0,0 +1,269 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口抓拍图片分页遍历
+ */
+package snapshot
+
+import "time"
+
+// 设备单次查询允许的最大记录序号
+const maxQueryIndex = 4096
+
+// 默认分页大小
+const defaultQueryPageSize = 100
+
+// QueryFilter 抓拍图遍历过滤条件（可序列化，便于与游标状态一起保存）
+type QueryFilter struct {
+	// 通道UUID（必填）
+	UUID string `json:"uuid"`
+	// 开始时间（UTC时间戳，单位秒）
+	BeginTime int64 `json:"beginTime"`
+	// 结束时间（UTC时间戳，单位秒，0表示创建游标时的当前时间）
+	EndTime int64 `json:"endTime"`
+	// 是否按摄像机本地时间查询（默认按UTC时间查询）
+	LocalTime bool `json:"localTime,omitempty"`
+	// 抓拍图类型（选填）
+	SnapshotType *SnapshotType `json:"snapshotType,omitempty"`
+	// 车道号（选填）
+	LaneId *int `json:"laneId,omitempty"`
+	// 车辆类型（选填）
+	VehicleType *SnapshotVehicleType `json:"vehicleType,omitempty"`
+	// 抓拍机类型（选填）
+	SnapshotDevType *SnapshotDeviceType `json:"snapshotDevType,omitempty"`
+	// 违章类型（选填）
+	VehicleRegulationType *SnapshotVehicleRegulationType `json:"vehicleRegulationType,omitempty"`
+	// 分页大小（默认100，最大4096）
+	PageSize int `json:"pageSize,omitempty"`
+}
+
+// 构建查询参数
+func (f *QueryFilter) params(begin, end int64) []QueryParam {
+	timeType := 1
+	if f.LocalTime {
+		timeType = 0
+	}
+	params := []QueryParam{
+		QueryWithBeginTime(begin),
+		QueryWithEndTime(end),
+		QueryWithTimeType(timeType),
+	}
+	if f.SnapshotType != nil {
+		params = append(params, QueryWithSnapshotType(*f.SnapshotType))
+	}
+	if f.LaneId != nil {
+		params = append(params, QueryWithLaneId(*f.LaneId))
+	}
+	if f.VehicleType != nil {
+		params = append(params, QueryWithVehicleType(*f.VehicleType))
+	}
+	if f.SnapshotDevType != nil {
+		params = append(params, QueryWithSnapshotDevType(*f.SnapshotDevType))
+	}
+	if f.VehicleRegulationType != nil {
+		params = append(params, QueryWithVehicleRegulationType(*f.VehicleRegulationType))
+	}
+	return params
+}
+
+// 分页大小
+func (f *QueryFilter) pageSize() int {
+	switch {
+	case f.PageSize <= 0:
+		return defaultQueryPageSize
+	case f.PageSize > maxQueryIndex:
+		return maxQueryIndex
+	default:
+		return f.PageSize
+	}
+}
+
+// QueryCursorState 抓拍图遍历游标状态（可JSON序列化保存，用于断线后继续遍历）
+type QueryCursorState struct {
+	// 过滤条件
+	Filter QueryFilter `json:"filter"`
+	// 当前查询时间窗口开始时间
+	WindowBegin int64 `json:"windowBegin"`
+	// 当前查询时间窗口结束时间
+	WindowEnd int64 `json:"windowEnd"`
+	// 当前时间窗口内的总记录数（0表示尚未查询）
+	TotalNum int `json:"totalNum"`
+	// 下一条待返回记录在当前时间窗口内的序号（从1开始）
+	NextIndex int `json:"nextIndex"`
+	// 超出设备查询上限而无法获取的记录数（单秒内记录数超过4096时发生）
+	Truncated int `json:"truncated,omitempty"`
+	// 是否遍历完毕
+	Done bool `json:"done"`
+}
+
+// QueryCursor 抓拍图遍历游标
+//
+// 自动分页，并在时间窗口内记录数超过设备查询上限（4096）时自动拆分时间窗口。
+// 游标不是并发安全的。
+//
+//	cursor := manager.NewQueryCursor(filter)
+//	for cursor.Next() {
+//		record := cursor.Record()
+//		...
+//	}
+//	if err := cursor.Err(); err != nil {
+//		// 保存cursor.State()，重连后通过ResumeQueryCursor继续遍历
+//	}
+type QueryCursor struct {
+	manager *Manager         // 抓拍管理器
+	state   QueryCursorState // 游标状态
+	page    []SnapshotRecord // 当前页记录
+	record  SnapshotRecord   // 当前记录
+	err     error            // 异常信息
+}
+
+// NewQueryCursor 创建抓拍图遍历游标
+//
+//	@param filter: 过滤条件
+//	@return 抓拍图遍历游标
+func (p *Manager) NewQueryCursor(filter QueryFilter) *QueryCursor {
+	if filter.EndTime <= 0 {
+		filter.EndTime = time.Now().Unix()
+	}
+	return &QueryCursor{
+		manager: p,
+		state: QueryCursorState{
+			Filter:      filter,
+			WindowBegin: filter.BeginTime,
+			WindowEnd:   filter.EndTime,
+			NextIndex:   1,
+			Done:        filter.BeginTime > filter.EndTime,
+		},
+	}
+}
+
+// ResumeQueryCursor 从保存的游标状态继续遍历
+//
+//	@param state: 游标状态（来自QueryCursor.State）
+//	@return 抓拍图遍历游标
+func (p *Manager) ResumeQueryCursor(state QueryCursorState) *QueryCursor {
+	if state.NextIndex < 1 {
+		state.NextIndex = 1
+	}
+	return &QueryCursor{
+		manager: p,
+		state:   state,
+	}
+}
+
+// State 获取游标状态（位于最近一次Next返回的记录之后）
+func (c *QueryCursor) State() QueryCursorState {
+	return c.state
+}
+
+// Record 获取当前记录
+func (c *QueryCursor) Record() SnapshotRecord {
+	return c.record
+}
+
+// Err 获取遍历异常信息（遍历正常结束时返回nil）
+func (c *QueryCursor) Err() error {
+	return c.err
+}
+
+// 切换到下一个时间窗口
+func (c *QueryCursor) nextWindow() {
+	c.page = nil
+	c.state.TotalNum = 0
+	c.state.NextIndex = 1
+	if c.state.WindowEnd >= c.state.Filter.EndTime {
+		c.state.Done = true
+		return
+	}
+	c.state.WindowBegin = c.state.WindowEnd + 1
+	c.state.WindowEnd = c.state.Filter.EndTime
+}
+
+// 查询下一页
+func (c *QueryCursor) fetch() error {
+	for {
+		state := &c.state
+		begin := state.NextIndex
+		end := min(begin+state.Filter.pageSize()-1, maxQueryIndex)
+		params := append(state.Filter.params(state.WindowBegin, state.WindowEnd),
+			QueryWithBeginIndex(begin),
+			QueryWithEndIndex(end),
+		)
+		if state.TotalNum > 0 {
+			params = append(params, QueryWithTotalNum(state.TotalNum))
+		}
+		reply, err := c.manager.ImageQuery(state.Filter.UUID, params...)
+		if err != nil {
+			return err
+		}
+
+		// 首次查询时间窗口，检查是否需要拆分
+		if state.TotalNum == 0 {
+			if reply.TotalNum > maxQueryIndex && state.WindowEnd > state.WindowBegin {
+				state.WindowEnd = state.WindowBegin + (state.WindowEnd-state.WindowBegin)/2
+				continue
+			}
+			if reply.TotalNum > maxQueryIndex {
+				// 单秒内记录数超出上限，只能获取前4096条
+				state.Truncated += reply.TotalNum - maxQueryIndex
+				reply.TotalNum = maxQueryIndex
+			}
+			state.TotalNum = reply.TotalNum
+		}
+
+		// 时间窗口无记录或已取完
+		if state.TotalNum == 0 || begin > state.TotalNum || len(reply.ImageInfoList) == 0 {
+			c.nextWindow()
+			if state.Done {
+				return nil
+			}
+			continue
+		}
+
+		// 丢弃超出当前窗口记录数的部分（防止设备多返回）
+		page := reply.ImageInfoList
+		if remain := state.TotalNum - begin + 1; len(page) > remain {
+			page = page[:remain]
+		}
+		c.page = page
+		return nil
+	}
+}
+
+// Next 移动到下一条记录
+//
+//	@return 是否存在下一条记录（遍历完毕或发生异常时返回false，通过Err区分）
+func (c *QueryCursor) Next() bool {
+	if c.err != nil {
+		return false
+	}
+	for len(c.page) == 0 {
+		if c.state.Done {
+			return false
+		}
+		if c.state.TotalNum > 0 && c.state.NextIndex > c.state.TotalNum {
+			c.nextWindow()
+			continue
+		}
+		if err := c.fetch(); err != nil {
+			c.err = err
+			return false
+		}
+	}
+	c.record = c.page[0]
+	c.page = c.page[1:]
+	c.state.NextIndex++
+	return true
+}
+
+// All 遍历全部记录（记录较多时请使用Next逐条处理）
+//
+//	@return 全部记录
+//	@return 异常信息（发生异常时同时返回已获取的记录）
+func (c *QueryCursor) All() ([]SnapshotRecord, error) {
+	var records []SnapshotRecord
+	for c.Next() {
+		records = append(records, c.Record())
+	}
+	return records, c.err
+}
@@ -0,0 +1,215 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口抓拍图片查询响应解析（兼容不同固件版本）
+ */
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// 字段名统一转为小写的JSON对象
+type jsonObject map[string]json.RawMessage
+
+// 解析JSON对象（字段名统一转为小写）
+func parseJSONObject(data []byte) (jsonObject, bool) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil || raw == nil {
+		return nil, false
+	}
+	obj := make(jsonObject, len(raw))
+	for key, val := range raw {
+		obj[strings.ToLower(key)] = val
+	}
+	return obj, true
+}
+
+// 查找非null的字段
+func (o jsonObject) lookup(key string) (json.RawMessage, bool) {
+	val, ok := o[key]
+	if !ok || bytes.Equal(bytes.TrimSpace(val), []byte("null")) {
+		return nil, false
+	}
+	return val, true
+}
+
+// 获取整型字段（兼容数字与数字字符串）
+func (o jsonObject) getInt64(key string) (int64, bool) {
+	val, ok := o.lookup(key)
+	if !ok {
+		return 0, false
+	}
+	val = bytes.TrimSpace(val)
+	if len(val) > 0 && val[0] == '"' {
+		var str string
+		if err := json.Unmarshal(val, &str); err != nil {
+			return 0, false
+		}
+		val = []byte(strings.TrimSpace(str))
+	}
+	num, err := strconv.ParseInt(string(val), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return num, true
+}
+
+// 获取字符串字段（兼容数字）
+func (o jsonObject) getString(key string) (string, bool) {
+	val, ok := o.lookup(key)
+	if !ok {
+		return "", false
+	}
+	var str string
+	if err := json.Unmarshal(val, &str); err == nil {
+		return str, true
+	}
+	return string(bytes.TrimSpace(val)), true
+}
+
+// 获取布尔字段（兼容true/false、1/0及其字符串形式）
+func (o jsonObject) getBool(key string) (bool, bool) {
+	val, ok := o.lookup(key)
+	if !ok {
+		return false, false
+	}
+	str := strings.ToLower(strings.Trim(string(bytes.TrimSpace(val)), `"`))
+	switch str {
+	case "true", "1":
+		return true, true
+	case "false", "0", "":
+		return false, true
+	}
+	return false, false
+}
+
+// 解析关联录像信息
+func decodeSnapshotRecordInfo(obj jsonObject, info *SnapshotRecordInfo) {
+	val, ok := obj.lookup("recordinfo")
+	if !ok {
+		return
+	}
+	src, ok := parseJSONObject(val)
+	if !ok {
+		return
+	}
+	if v, ok := src.getBool("recordexist"); ok {
+		info.RecordExist = v
+	}
+	if v, ok := src.getInt64("starttime"); ok {
+		info.StartTime = v
+	}
+	if v, ok := src.getInt64("endtime"); ok {
+		info.EndTime = v
+	}
+}
+
+// 解析抓拍图记录（未出现的字段保持原值，便于用平铺字段补全）
+func decodeSnapshotRecord(obj jsonObject, record *SnapshotRecord) {
+	if v, ok := obj.getInt64("snaptime"); ok {
+		record.SnapTime = v
+	}
+	if v, ok := obj.getInt64("timetype"); ok {
+		record.TimeType = int(v)
+	}
+	if v, ok := obj.getString("contentid"); ok {
+		record.ContentId = v
+	}
+	if v, ok := obj.getInt64("contentsize"); ok && v >= 0 {
+		record.ContentSize = uint32(v)
+	}
+	if v, ok := obj.getInt64("laneid"); ok {
+		record.LaneId = int(v)
+	}
+	if v, ok := obj.getInt64("vehicletype"); ok {
+		record.VehicleType = SnapshotVehicleType(v)
+	}
+	if v, ok := obj.getInt64("vehicleregulationtype"); ok {
+		record.VehicleRegulationType = SnapshotVehicleRegulationType(v)
+	}
+	decodeSnapshotRecordInfo(obj, &record.RecordInfo)
+}
+
+// 是否包含抓拍图记录字段
+func hasSnapshotRecordFields(obj jsonObject) bool {
+	_, ok := obj.lookup("contentid")
+	return ok
+}
+
+// UnmarshalJSON 解析抓拍图查询响应
+//
+// 仅识别文档中的字段名（不区分大小写），兼容已知的固件差异：
+//   - 数值以字符串返回
+//   - 旧固件将抓拍图名称、大小、车道号等字段平铺在响应顶层，列表中仅包含抓拍时间
+func (r *QueryReply) UnmarshalJSON(data []byte) error {
+	obj, ok := parseJSONObject(data)
+	if !ok {
+		return errors.New("invalid snapshot query reply")
+	}
+
+	// 设备返回错误状态
+	if val, ok := obj.lookup("responsestatus"); ok {
+		if status, ok := parseJSONObject(val); ok {
+			if code, ok := status.getInt64("statuscode"); ok && code != 0 {
+				msg, _ := status.getString("statusstring")
+				if msg == "" {
+					msg = "snapshot query failed: " + strconv.FormatInt(code, 10)
+				}
+				return errors.New(msg)
+			}
+		}
+	}
+
+	// 分页信息
+	reply := QueryReply{}
+	if v, ok := obj.getInt64("totalnum"); ok {
+		reply.TotalNum = int(v)
+	}
+	if v, ok := obj.getInt64("beginindex"); ok {
+		reply.BeginIndex = int(v)
+	}
+	if v, ok := obj.getInt64("endindex"); ok {
+		reply.EndIndex = int(v)
+	}
+
+	// 旧固件平铺在顶层的记录字段
+	var flat *SnapshotRecord
+	if hasSnapshotRecordFields(obj) {
+		flat = &SnapshotRecord{}
+		decodeSnapshotRecord(obj, flat)
+	}
+
+	// 记录列表
+	if val, ok := obj.lookup("imageinfolist"); ok {
+		var items []json.RawMessage
+		if err := json.Unmarshal(val, &items); err != nil {
+			return err
+		}
+		reply.ImageInfoList = make([]SnapshotRecord, 0, len(items))
+		for _, item := range items {
+			itemObj, ok := parseJSONObject(item)
+			if !ok {
+				continue
+			}
+			var record SnapshotRecord
+			// 平铺字段仅在单条记录时可以确定归属
+			if flat != nil && len(items) == 1 {
+				record = *flat
+			}
+			decodeSnapshotRecord(itemObj, &record)
+			reply.ImageInfoList = append(reply.ImageInfoList, record)
+		}
+	}
+	if len(reply.ImageInfoList) == 0 && flat != nil {
+		reply.ImageInfoList = []SnapshotRecord{*flat}
+	}
+
+	// OK
+	*r = reply
+	return nil
+}
@@ -8,6 +8,9 @@ package snapshot
 import (
 	"net/url"
 	"strconv"
+	"time"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
 )
 
 // SnapshotType 抓拍图类型
@@ -238,15 +241,7 @@ func QueryWithOnceInquireFlag(val int) QueryParam {
 	}
 }
 
-// SnapshotImageInfo 抓拍图信息
-type SnapshotImageInfo struct {
-	// 抓拍时间（单位秒）
-	SnapTime int64 `json:"snapTime"`
-	// 抓拍时间类型（0-摄像机本地时间，1-UTC时间）
-	TimeType int `json:"timeType"`
-}
-
-// SnapshotRecordInfo 抓拍记录信息
+// SnapshotRecordInfo 抓拍关联录像信息
 type SnapshotRecordInfo struct {
 	// 是否存在关联录像
 	RecordExist bool `json:"recordExist"`
@@ -256,22 +251,16 @@ type SnapshotRecordInfo struct {
 	EndTime int64 `json:"endTime"`
 }
 
-// QueryReply 抓拍图查询响应
-type QueryReply struct {
-	// 总记录数
-	TotalNum int `json:"totalNum"`
-	// 当前开始记录数
-	BeginIndex int `json:"beginIndex"`
-	// 当前结束记录数
-	EndIndex int `json:"endIndex"`
-	// 抓拍图信息列表
-	ImageInfoList []SnapshotImageInfo `json:"imageInfoList"`
+// SnapshotRecord 抓拍图记录
+type SnapshotRecord struct {
+	// 抓拍时间（单位秒）
+	SnapTime int64 `json:"snapTime"`
+	// 抓拍时间类型（0-摄像机本地时间，1-UTC时间）
+	TimeType int `json:"timeType"`
+	// 抓拍图名称（长度63字符，用于抓拍图片下载）
+	ContentId string `json:"contentId"`
 	// 抓拍图大小（单位：字节）
 	ContentSize uint32 `json:"contentSize"`
-	// 抓拍图名称（长度63字符）
-	ContentId string `json:"contentId"`
-	// 抓拍记录信息
-	RecordInfo SnapshotRecordInfo `json:"recordInfo"`
 	// 车道号
 	//
 	//	取值范围：[0,3]
@@ -280,6 +269,27 @@ type QueryReply struct {
 	VehicleType SnapshotVehicleType `json:"vehicleType"`
 	// 违章类型
 	VehicleRegulationType SnapshotVehicleRegulationType `json:"vehicleRegulationType"`
+	// 关联录像信息
+	RecordInfo SnapshotRecordInfo `json:"recordInfo"`
+}
+
+// Time 抓拍时间
+//
+//	抓拍时间类型为摄像机本地时间时，返回的时间按UTC解释，需调用方结合设备时区换算
+func (r *SnapshotRecord) Time() time.Time {
+	return time.Unix(r.SnapTime, 0).UTC()
+}
+
+// QueryReply 抓拍图查询响应
+type QueryReply struct {
+	// 总记录数
+	TotalNum int `json:"totalNum"`
+	// 当前开始记录数
+	BeginIndex int `json:"beginIndex"`
+	// 当前结束记录数
+	EndIndex int `json:"endIndex"`
+	// 抓拍图记录列表（每条记录为一张抓拍图）
+	ImageInfoList []SnapshotRecord `json:"imageInfoList"`
 }
 
 // ImageQuery 抓拍图查询
@@ -289,6 +299,11 @@ type QueryReply struct {
 //	@return 查询结果
 //	@return 异常信息
 func (p *Manager) ImageQuery(uuid string, params ...QueryParam) (*QueryReply, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureSnapshot); err != nil {
+		return nil, err
+	}
+
 	// 获取Socket连接的HTTP客户端
 	client := p.connInstance.LockHttpClient()
 	defer p.connInstance.Unlock()
@@ -6,9 +6,14 @@
 package snapshot
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"io"
+	"mime/multipart"
 	"strings"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
 )
 
 // SnapActionParams 手动抓拍参数
@@ -24,57 +29,144 @@ type SnapActionReply struct {
 	FileName    string // 抓拍图片文件名
 }
 
-// SnapAction 手动抓拍
+// SnapActionImage 手动抓拍图片
+type SnapActionImage struct {
+	Index       int    // 图片序号（从0开始，按响应中的顺序）
+	FormName    string // 表单字段名
+	ContentType string // 抓拍图片格式
+	FileName    string // 抓拍图片文件名
+	Size        int64  // 抓拍图片大小（单位：字节）
+	Data        []byte // 抓拍图片数据（图片已写入调用方提供的写入器时为空）
+}
+
+// SnapActionMultiReply 手动抓拍响应（包含全部图片与元数据）
+type SnapActionMultiReply struct {
+	Images   []SnapActionImage // 全部抓拍图片
+	Metadata []json.RawMessage // 随图片返回的JSON元数据
+}
+
+// SnapActionImageWriter 抓拍图片写入器提供函数
+//
+//	@param image: 抓拍图片信息（此时Size与Data尚未填充）
+//	@return 图片写入器（返回nil时图片数据缓存在SnapActionImage.Data中）
+//	@return 异常信息（返回异常时终止读取）
+type SnapActionImageWriter func(image *SnapActionImage) (io.Writer, error)
+
+// 是否为图片部分
+func isSnapActionImagePart(part *multipart.Part) bool {
+	contentType := part.Header.Get("Content-Type")
+	if strings.HasPrefix(contentType, "image/") || strings.Contains(contentType, "jpeg") {
+		return true
+	}
+	// 部分固件未设置图片类型，仅通过文件名区分
+	return part.FileName() != "" && !strings.Contains(contentType, "json")
+}
+
+// SnapActionMulti 手动抓拍（返回全部图片与JSON元数据）
+//
+// 以流的方式读取响应，图片可以直接写入调用方提供的写入器，不会落盘到临时文件。
 //
 //	@param params: 手动抓拍参数
-//	@return: 手动抓拍响应
-//	@return: 错误信息
-func (p *Manager) SnapAction(params SnapActionParams) (*SnapActionReply, error) {
+//	@param writer: 图片写入器提供函数（为nil时全部图片缓存在内存中）
+//	@return 手动抓拍响应
+//	@return 异常信息
+func (p *Manager) SnapActionMulti(params SnapActionParams, writer SnapActionImageWriter) (*SnapActionMultiReply, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureSnapshot); err != nil {
+		return nil, err
+	}
+
 	// 获取Socket连接的HTTP客户端
 	client := p.connInstance.LockHttpClient()
 	defer p.connInstance.Unlock()
 
-	// 发送请求
-	form, _, err := client.Post("/SDCAPI/V1.0/Storage/Snapshot/SnapAction").
+	// 发送请求并逐个读取响应
+	reply := &SnapActionMultiReply{}
+	_, err := client.Post("/SDCAPI/V1.0/Storage/Snapshot/SnapAction").
 		SetJSON(&params).
-		DecodeFormData(1024 * 1024 * 5)
+		DecodeMultipart(func(part *multipart.Part) error {
+			// JSON元数据
+			if strings.Contains(part.Header.Get("Content-Type"), "json") {
+				data, err := io.ReadAll(part)
+				if err != nil {
+					return err
+				}
+				reply.Metadata = append(reply.Metadata, data)
+				return nil
+			}
+			// 跳过非图片部分
+			if !isSnapActionImagePart(part) {
+				return nil
+			}
+			// 图片
+			image := SnapActionImage{
+				Index:       len(reply.Images),
+				FormName:    part.FormName(),
+				ContentType: part.Header.Get("Content-Type"),
+				FileName:    part.FileName(),
+			}
+			var dst io.Writer
+			if writer != nil {
+				w, err := writer(&image)
+				if err != nil {
+					return err
+				}
+				dst = w
+			}
+			var buf *bytes.Buffer
+			if dst == nil {
+				buf = &bytes.Buffer{}
+				dst = buf
+			}
+			n, err := io.Copy(dst, part)
+			if err != nil {
+				return err
+			}
+			image.Size = n
+			if buf != nil {
+				image.Data = buf.Bytes()
+			}
+			reply.Images = append(reply.Images, image)
+			return nil
+		})
 	if err != nil {
 		return nil, err
 	}
-	defer form.RemoveAll()
-
-	// 读取响应
-	// 遍历所有表单键
-	for _, fs := range form.File {
-		// 遍历所有表单值
-		for _, fh := range fs {
-			// 跳过非图片文件
-			if !strings.Contains(fh.Header.Get("Content-Type"), "jpeg") {
-				continue
-			}
 
-			// 打开图片文件
-			file, err := fh.Open()
-			if err != nil {
-				return nil, err
-			}
-			defer file.Close()
+	// OK
+	return reply, nil
+}
 
-			// 读取图片数据
-			data, err := io.ReadAll(file)
-			if err != nil {
-				return nil, err
-			}
+// SnapAction 手动抓拍
+//
+//	仅返回第一张JPEG图片（不存在时返回第一张图片），需要全部图片与元数据时请使用SnapActionMulti
+//
+//	@param params: 手动抓拍参数
+//	@return: 手动抓拍响应
+//	@return: 错误信息
+func (p *Manager) SnapAction(params SnapActionParams) (*SnapActionReply, error) {
+	// 执行抓拍
+	reply, err := p.SnapActionMulti(params, nil)
+	if err != nil {
+		return nil, err
+	}
 
-			// OK
-			return &SnapActionReply{
-				Data:        data,
-				ContentType: fh.Header.Get("Content-Type"),
-				FileName:    fh.Filename,
-			}, nil
+	// 优先返回JPEG图片
+	if len(reply.Images) == 0 {
+		return nil, errors.New("no file")
+	}
+	image := reply.Images[0]
+	for _, item := range reply.Images {
+		if strings.Contains(item.ContentType, "jpeg") {
+			image = item
+			break
 		}
 	}
 
-	// 错误
-	return nil, errors.New("no file")
+	// OK
+	return &SnapActionReply{
+		Data:        image.Data,
+		ContentType: image.ContentType,
+		FileName:    image.FileName,
+	}, nil
 }
@@ -0,0 +1,29 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口录像回放与下载模块
+ */
+package storage
+
+import (
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
+	"github.com/kaicen-x/holosens-sdc-sdk/pkg/httpconn"
+)
+
+// Manager 录像回放与下载管理器
+type Manager struct {
+	connInstance *httpconn.Connect    // Socket连接实例
+	capabilities *common.Capabilities // 设备能力（为nil时不检查）
+}
+
+// NewManager 创建录像回放与下载管理器
+func NewManager(connInstance *httpconn.Connect) *Manager {
+	return &Manager{
+		connInstance: connInstance,
+	}
+}
+
+// SetCapabilities 设置设备能力（调用接口前检查，已确认不支持时返回common.ErrUnsupported）
+func (p *Manager) SetCapabilities(capabilities *common.Capabilities) {
+	p.capabilities = capabilities
+}
@@ -0,0 +1,121 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口录像下载
+ */
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
+)
+
+var (
+	// ErrNoRecordContent：下载响应中没有录像内容
+	ErrNoRecordContent = errors.New("no record content")
+)
+
+// RecordDownloadParams 录像下载参数
+type RecordDownloadParams struct {
+	// 通道UUID（必填）
+	UUID string
+	// 开始时间（UTC时间戳，单位秒）
+	StartTime int64
+	// 结束时间（UTC时间戳，单位秒）
+	EndTime int64
+	// 录像文件名称（选填，来自录像查询结果；填写时按文件下载，否则按时间窗口下载）
+	ContentId string
+}
+
+// RecordDownloadReply 录像下载响应
+type RecordDownloadReply struct {
+	ContentType string // 录像格式（如video/mp4、video/mp2t）
+	ContentSize int64  // 录像大小（实际写入的字节数）
+	FileName    string // 录像文件名
+}
+
+// RecordDownload 录像下载
+//
+// 按时间窗口（或录像文件名称）下载录像，录像以流的方式写入输出，不会整体缓存在内存中。
+//
+//	@param params: 录像下载参数
+//	@param w: 录像输出
+//	@return 录像下载响应
+//	@return 异常信息
+func (p *Manager) RecordDownload(params RecordDownloadParams, w io.Writer) (*RecordDownloadReply, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureRecord); err != nil {
+		return nil, err
+	}
+
+	// 获取Socket连接的HTTP客户端
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 构建请求
+	req := client.Get("/SDCAPI/V1.0/Storage/Record/Download").
+		SetContentType("application/x-www-form-urlencoded").
+		SetQuery("UUID", params.UUID)
+	if params.ContentId != "" {
+		req.SetQuery("ContentId", params.ContentId)
+	}
+	if params.StartTime > 0 || params.EndTime > 0 {
+		req.SetQuery("BeginTime", strconv.FormatInt(params.StartTime, 10)).
+			SetQuery("EndTime", strconv.FormatInt(params.EndTime, 10))
+	}
+
+	// 发送请求
+	res, err := req.Send()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		// 读完剩余数据，避免残留数据影响同一连接上的后续请求
+		io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+	}()
+
+	// 检查响应状态码
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+		if len(body) > 0 {
+			return nil, errors.New(string(body))
+		}
+		return nil, errors.New(res.Status)
+	}
+
+	// 设备以JSON返回错误信息
+	reply := &RecordDownloadReply{
+		ContentType: res.Header.Get("Content-Type"),
+	}
+	if strings.Contains(reply.ContentType, "json") {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+		var status common.Response[common.ResponseStatus]
+		if json.Unmarshal(body, &status) == nil && status.ResponseStatus.StatusCode != 0 {
+			return nil, errors.New(status.ResponseStatus.StatusString)
+		}
+		return nil, ErrNoRecordContent
+	}
+	if _, dispParams, err := mime.ParseMediaType(res.Header.Get("Content-Disposition")); err == nil {
+		reply.FileName = dispParams["filename"]
+	}
+
+	// 写入录像数据
+	n, err := io.Copy(w, client.DeadlineReader(res.Body))
+	reply.ContentSize = n
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, ErrNoRecordContent
+	}
+
+	// OK
+	return reply, nil
+}
@@ -0,0 +1,217 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口录像查询
+ */
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
+)
+
+var (
+	// ErrRecordResultTruncated：时间窗口内的录像段超过单次查询上限且无法继续拆分
+	ErrRecordResultTruncated = errors.New("record query result truncated")
+)
+
+// RecordType 录像类型
+type RecordType int
+
+const (
+	// 录像类型：全部
+	RecordType_All RecordType = 0
+	// 录像类型：定时录像
+	RecordType_Timer RecordType = 1
+	// 录像类型：告警录像
+	RecordType_Alarm RecordType = 2
+	// 录像类型：手动录像
+	RecordType_Manual RecordType = 3
+	// 录像类型：智能录像
+	RecordType_Intelligent RecordType = 4
+)
+
+// RecordQueryParam 录像查询参数构建器
+type RecordQueryParam func(url.Values)
+
+// RecordQueryWithBeginTime 录像查询参数：开始时间
+//
+//	UTC时间戳，单位秒
+func RecordQueryWithBeginTime(val int64) RecordQueryParam {
+	return func(values url.Values) {
+		values.Set("BeginTime", strconv.FormatInt(val, 10))
+	}
+}
+
+// RecordQueryWithEndTime 录像查询参数：结束时间
+//
+//	UTC时间戳，单位秒
+func RecordQueryWithEndTime(val int64) RecordQueryParam {
+	return func(values url.Values) {
+		values.Set("EndTime", strconv.FormatInt(val, 10))
+	}
+}
+
+// RecordQueryWithRecordType 录像查询参数：录像类型
+func RecordQueryWithRecordType(val RecordType) RecordQueryParam {
+	return func(values url.Values) {
+		values.Set("RecordType", strconv.Itoa(int(val)))
+	}
+}
+
+// RecordQueryWithBeginIndex 录像查询参数：当前开始记录数
+//
+//	取值范围：[1,4096]
+func RecordQueryWithBeginIndex(val int) RecordQueryParam {
+	return func(values url.Values) {
+		values.Set("BeginIndex", strconv.Itoa(val))
+	}
+}
+
+// RecordQueryWithEndIndex 录像查询参数：当前结束记录数
+//
+//	取值范围：[1,4096]
+func RecordQueryWithEndIndex(val int) RecordQueryParam {
+	return func(values url.Values) {
+		values.Set("EndIndex", strconv.Itoa(val))
+	}
+}
+
+// RecordSegment 录像段信息
+type RecordSegment struct {
+	// 录像开始时间（UTC时间戳，单位秒）
+	StartTime int64 `json:"startTime"`
+	// 录像结束时间（UTC时间戳，单位秒）
+	EndTime int64 `json:"endTime"`
+	// 录像类型
+	RecordType RecordType `json:"recordType"`
+	// 录像文件名称（用于录像下载，部分固件不返回）
+	ContentId string `json:"contentId"`
+	// 录像文件大小（单位：字节，部分固件不返回）
+	ContentSize uint64 `json:"contentSize"`
+}
+
+// RecordQueryReply 录像查询响应
+type RecordQueryReply struct {
+	// 总记录数
+	TotalNum int `json:"totalNum"`
+	// 当前开始记录数
+	BeginIndex int `json:"beginIndex"`
+	// 当前结束记录数
+	EndIndex int `json:"endIndex"`
+	// 录像段列表
+	RecordList []RecordSegment `json:"recordInfoList"`
+}
+
+// RecordQuery 录像查询
+//
+//	@param uuid: 设备通道UUID
+//	@param params: 查询参数
+//	@return 查询结果
+//	@return 异常信息
+func (p *Manager) RecordQuery(uuid string, params ...RecordQueryParam) (*RecordQueryReply, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureRecord); err != nil {
+		return nil, err
+	}
+
+	// 获取Socket连接的HTTP客户端
+	client := p.connInstance.LockHttpClient()
+	defer p.connInstance.Unlock()
+
+	// 构建查询请求
+	req := client.Get("/SDCAPI/V1.0/Storage/Record/Inquire").
+		SetContentType("application/x-www-form-urlencoded").
+		AddQuery("UUID", uuid)
+	// 检查参数，执行添加
+	for _, param := range params {
+		param(req.GetQuery())
+	}
+	// 发送请求
+	var reply RecordQueryReply
+	_, err := req.DecodeJSON(&reply)
+	if err != nil {
+		return nil, err
+	}
+
+	// OK
+	return &reply, nil
+}
+
+// 单次查询可分页的最大记录数
+const recordQueryMaxIndex = 4096
+
+// RecordSearch 查询时间窗口内的全部录像段（自动分页）
+//
+// 时间窗口内的录像段超过单次查询上限（4096条）时，自动将时间窗口二分后分别查询并合并结果；
+// 时间窗口无法继续拆分时返回ErrRecordResultTruncated。
+//
+//	@param uuid: 设备通道UUID
+//	@param beginTime: 开始时间（UTC时间戳，单位秒）
+//	@param endTime: 结束时间（UTC时间戳，单位秒）
+//	@return 与时间窗口有交集的录像段（按开始时间升序）
+//	@return 异常信息
+func (p *Manager) RecordSearch(uuid string, beginTime, endTime int64) ([]RecordSegment, error) {
+	segments, err := p.recordSearch(uuid, beginTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	// 排序并去除拆分时间窗口导致的重复录像段
+	sort.SliceStable(segments, func(i, j int) bool { return segments[i].StartTime < segments[j].StartTime })
+	result := segments[:0]
+	seen := make(map[RecordSegment]bool, len(segments))
+	for _, segment := range segments {
+		if !seen[segment] {
+			seen[segment] = true
+			result = append(result, segment)
+		}
+	}
+	return result, nil
+}
+
+// 分页查询时间窗口内的录像段（超出单次查询上限时拆分时间窗口）
+func (p *Manager) recordSearch(uuid string, beginTime, endTime int64) ([]RecordSegment, error) {
+	const pageSize = 100
+	var segments []RecordSegment
+	for begin := 1; begin <= recordQueryMaxIndex; begin += pageSize {
+		reply, err := p.RecordQuery(uuid,
+			RecordQueryWithBeginTime(beginTime),
+			RecordQueryWithEndTime(endTime),
+			RecordQueryWithBeginIndex(begin),
+			RecordQueryWithEndIndex(min(begin+pageSize-1, recordQueryMaxIndex)),
+		)
+		if err != nil {
+			return nil, err
+		}
+		// 超出单次查询上限，拆分时间窗口
+		if reply.TotalNum > recordQueryMaxIndex {
+			if endTime-beginTime < 2 {
+				return nil, fmt.Errorf("%w: %d records in [%d, %d]", ErrRecordResultTruncated, reply.TotalNum, beginTime, endTime)
+			}
+			mid := beginTime + (endTime-beginTime)/2
+			left, err := p.recordSearch(uuid, beginTime, mid)
+			if err != nil {
+				return nil, err
+			}
+			right, err := p.recordSearch(uuid, mid+1, endTime)
+			if err != nil {
+				return nil, err
+			}
+			return append(left, right...), nil
+		}
+		for _, segment := range reply.RecordList {
+			if segment.EndTime >= beginTime && segment.StartTime <= endTime {
+				segments = append(segments, segment)
+			}
+		}
+		if len(reply.RecordList) == 0 || begin+pageSize > reply.TotalNum {
+			break
+		}
+	}
+	return segments, nil
+}
@@ -0,0 +1,105 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口录像回放RTSP地址
+ */
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
+)
+
+// RTSP默认端口
+const defaultRtspPort = 554
+
+// 录像回放RTSP默认路径模板（%d为通道序号，从1开始）
+const defaultRtspPlaybackPath = "/LiveMedia/ch%d/Media1/Playback"
+
+// RtspPlaybackParams 录像回放RTSP地址参数
+type RtspPlaybackParams struct {
+	// 设备地址（选填，默认使用会话连接的远端地址）
+	Host string
+	// RTSP端口（选填，默认554）
+	Port int
+	// 通道序号（从1开始，选填，默认1）
+	Channel int
+	// 路径模板（选填，%d为通道序号，默认"/LiveMedia/ch%d/Media1/Playback"）
+	//
+	//	不同固件的路径可能不同，可按实际情况填写
+	PathTemplate string
+	// 开始时间
+	StartTime time.Time
+	// 结束时间
+	EndTime time.Time
+	// RTSP认证用户名（选填，填写时写入地址）
+	Username string
+	// RTSP认证密码（选填）
+	Password string
+}
+
+// RtspPlaybackURL 构建录像回放RTSP地址
+//
+//	时间参数使用UTC时间，格式为YYYYMMDDThhmmssZ，如：
+//	rtsp://192.168.1.10:554/LiveMedia/ch1/Media1/Playback?starttime=20250302T001700Z&endtime=20250302T001800Z
+//
+//	@param params: 录像回放RTSP地址参数
+//	@return RTSP地址
+//	@return 异常信息
+func (p *Manager) RtspPlaybackURL(params RtspPlaybackParams) (string, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureRecord); err != nil {
+		return "", err
+	}
+
+	// 设备地址
+	host := params.Host
+	if host == "" {
+		addr := p.connInstance.RemoteAddr()
+		if addr == nil {
+			return "", errors.New("unknown device address")
+		}
+		h, _, err := net.SplitHostPort(addr.String())
+		if err != nil {
+			return "", err
+		}
+		host = h
+	}
+	port := params.Port
+	if port <= 0 {
+		port = defaultRtspPort
+	}
+	channel := params.Channel
+	if channel <= 0 {
+		channel = 1
+	}
+	pathTemplate := params.PathTemplate
+	if pathTemplate == "" {
+		pathTemplate = defaultRtspPlaybackPath
+	}
+	if !params.EndTime.After(params.StartTime) {
+		return "", errors.New("invalid time window")
+	}
+
+	// 构建地址
+	const layout = "20060102T150405Z"
+	u := url.URL{
+		Scheme: "rtsp",
+		Host:   net.JoinHostPort(host, strconv.Itoa(port)),
+		Path:   fmt.Sprintf(pathTemplate, channel),
+		RawQuery: url.Values{
+			"starttime": {params.StartTime.UTC().Format(layout)},
+			"endtime":   {params.EndTime.UTC().Format(layout)},
+		}.Encode(),
+	}
+	if params.Username != "" {
+		u.User = url.UserPassword(params.Username, params.Password)
+	}
+	return u.String(), nil
+}
@@ -0,0 +1,316 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrUnsupported 设备不支持该功能
+//
+//	管理器在调用接口前检查设备能力，已确认不支持时返回*UnsupportedError，可使用errors.Is(err, ErrUnsupported)判断
+var ErrUnsupported = errors.New("unsupported by device")
+
+// Feature 设备功能
+type Feature string
+
+const (
+	FeatureTime           Feature = "time"           // 设备时间管理
+	FeatureNetwork        Feature = "network"        // 网络配置管理
+	FeatureMaintain       Feature = "maintain"       // 设备维护（重启、恢复默认配置、数据清除）
+	FeatureUpgrade        Feature = "upgrade"        // 设备升级
+	FeatureConfigFile     Feature = "configFile"     // 设备配置文件导入导出
+	FeatureUserManage     Feature = "userManage"     // 用户管理
+	FeatureLog            Feature = "log"            // 日志查询与导出
+	FeatureDiagnosis      Feature = "diagnosis"      // 诊断信息包导出
+	FeatureSnapshot       Feature = "snapshot"       // 抓拍与图片下载
+	FeatureSnapshotConfig Feature = "snapshotConfig" // 抓拍配置
+	FeatureRecord         Feature = "record"         // 录像查询、下载与回放
+	FeatureMetadata       Feature = "metadata"       // 智能元数据订阅
+	FeatureTargetLib      Feature = "targetLib"      // 目标库与目标记录管理
+	FeatureTargetExternal Feature = "targetExternal" // 目标记录外部ID（externalId，SDC 9.0.0-LG0001版本新增）
+)
+
+// UnsupportedError 设备不支持该功能的异常
+type UnsupportedError struct {
+	Feature Feature // 功能
+	Reason  string  // 原因
+}
+
+// Error 异常信息
+func (e *UnsupportedError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("%s: %s", ErrUnsupported, e.Feature)
+	}
+	return fmt.Sprintf("%s: %s (%s)", ErrUnsupported, e.Feature, e.Reason)
+}
+
+// Is 支持errors.Is(err, ErrUnsupported)
+func (e *UnsupportedError) Is(target error) bool {
+	return target == ErrUnsupported
+}
+
+// Version 设备软件版本
+type Version struct {
+	Major  int    // 主版本号
+	Minor  int    // 次版本号
+	Patch  int    // 修订号
+	Branch string // 分支版本（如“9.0.0-LG0001”中的“LG0001”，主线版本为空）
+	Raw    string // 原始版本字符串
+}
+
+// 版本号匹配（兼容“SDC 11.1.0.SPC100”、“9.0.0-LG0001”等格式）
+var versionPattern = regexp.MustCompile(`(\d+)\.(\d+)(?:\.(\d+))?(?:-([A-Za-z]+\d*))?`)
+
+// 分支版本匹配（字母前缀+序号）
+var branchPattern = regexp.MustCompile(`^([A-Za-z]+)(\d*)$`)
+
+// ParseVersion 解析设备软件版本
+//
+//	@param s: 版本字符串（如BaseInfoQueryReply.SoftVersion）
+//	@return 软件版本
+//	@return 是否解析成功
+func ParseVersion(s string) (Version, bool) {
+	match := versionPattern.FindStringSubmatch(s)
+	if match == nil {
+		return Version{Raw: s}, false
+	}
+	v := Version{Raw: s}
+	v.Major, _ = strconv.Atoi(match[1])
+	v.Minor, _ = strconv.Atoi(match[2])
+	if match[3] != "" {
+		v.Patch, _ = strconv.Atoi(match[3])
+	}
+	v.Branch = strings.ToUpper(match[4])
+	return v, true
+}
+
+// 拆分分支版本（前缀与序号）
+func splitBranch(branch string) (string, int) {
+	match := branchPattern.FindStringSubmatch(branch)
+	if match == nil {
+		return branch, 0
+	}
+	num, _ := strconv.Atoi(match[2])
+	return strings.ToUpper(match[1]), num
+}
+
+// Satisfies 是否满足最低版本要求
+//
+//	最低版本为分支版本（如“9.0.0-LG0001”）时，要求设备为同一分支且版本号与分支序号均不低于最低版本；
+//	最低版本为主线版本时仅比较版本号
+func (v Version) Satisfies(minVersion Version) bool {
+	if minVersion.Branch != "" {
+		prefix, num := splitBranch(v.Branch)
+		minPrefix, minNum := splitBranch(minVersion.Branch)
+		if v.Branch == "" || prefix != minPrefix {
+			return false
+		}
+		if cmp := v.Compare(minVersion); cmp != 0 {
+			return cmp > 0
+		}
+		return num >= minNum
+	}
+	return v.Compare(minVersion) >= 0
+}
+
+// Compare 比较版本（小于、等于、大于分别返回-1、0、1，仅比较版本号）
+func (v Version) Compare(o Version) int {
+	for _, d := range [][2]int{{v.Major, o.Major}, {v.Minor, o.Minor}, {v.Patch, o.Patch}} {
+		if d[0] != d[1] {
+			if d[0] < d[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// String 版本字符串
+func (v Version) String() string {
+	if v.Branch != "" {
+		return fmt.Sprintf("%d.%d.%d-%s", v.Major, v.Minor, v.Patch, v.Branch)
+	}
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// FeatureRequirement 功能判定规则
+type FeatureRequirement struct {
+	// 最低软件版本（选填，分支版本如“9.0.0-LG0001”要求设备为同一分支）
+	MinVersion string
+	// 通道功能（ChannelInfo.FuncList）中表示该功能的键（选填，任一键存在且值不为"0"时视为支持）
+	FuncKeys []string
+	// 探测接口（选填，GET请求返回2xx时视为支持，返回404或501时视为不支持，其他状态码不做判定）
+	ProbePath string
+}
+
+// FeatureRequirements 功能判定规则（可按设备固件实际情况修改）
+var FeatureRequirements = map[Feature]FeatureRequirement{
+	FeatureTime:           {ProbePath: "/SDCAPI/V1.0/System/Time"},
+	FeatureNetwork:        {ProbePath: "/SDCAPI/V1.0/Network/Interface"},
+	FeatureUpgrade:        {ProbePath: "/SDCAPI/V1.0/System/Upgrade/Status"},
+	FeatureUserManage:     {ProbePath: "/SDCAPI/V1.0/Users"},
+	FeatureLog:            {ProbePath: "/SDCAPI/V1.0/System/Log"},
+	FeatureSnapshot:       {FuncKeys: []string{"snapshot"}},
+	FeatureSnapshotConfig: {FuncKeys: []string{"snapshot"}},
+	FeatureRecord:         {FuncKeys: []string{"record", "storage"}},
+	FeatureMetadata:       {FuncKeys: []string{"metadata"}, ProbePath: "/SDCAPI/V2.0/Metadata/Subscription"},
+	FeatureTargetLib:      {ProbePath: "/SDCAPI/V1.0/FaceApp/FaceRecog/FaceLibs/Libs"},
+	FeatureTargetExternal: {MinVersion: "9.0.0-LG0001"},
+}
+
+// CapabilityState 功能支持状态
+type CapabilityState int
+
+const (
+	CapabilityUnknown     CapabilityState = iota // 未知（允许调用）
+	CapabilitySupported                          // 支持
+	CapabilityUnsupported                        // 不支持
+)
+
+// 显式设置的功能状态
+type capabilityEntry struct {
+	state  CapabilityState // 支持状态
+	reason string          // 原因
+}
+
+// Capabilities 设备能力（并发安全，nil表示未知，不限制调用）
+//
+// 由软件版本、通道功能（FuncList）与探测结果共同确定，显式设置（Set）的结果优先。
+type Capabilities struct {
+	mtx        sync.RWMutex                // 读写锁
+	version    Version                     // 软件版本
+	hasVersion bool                        // 是否已设置软件版本
+	funcs      map[string]string           // 通道功能（全部通道合并）
+	entries    map[Feature]capabilityEntry // 显式设置的功能状态
+}
+
+// NewCapabilities 创建设备能力
+func NewCapabilities() *Capabilities {
+	return &Capabilities{
+		funcs:   make(map[string]string),
+		entries: make(map[Feature]capabilityEntry),
+	}
+}
+
+// SetVersion 设置软件版本
+func (c *Capabilities) SetVersion(softVersion string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.version, c.hasVersion = ParseVersion(softVersion)
+}
+
+// Version 获取软件版本
+func (c *Capabilities) Version() (Version, bool) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return c.version, c.hasVersion
+}
+
+// AddFuncList 合并通道功能
+func (c *Capabilities) AddFuncList(funcs map[string]string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	for key, val := range funcs {
+		// 任一通道支持即视为支持
+		if old, ok := c.funcs[key]; !ok || old == "0" {
+			c.funcs[key] = val
+		}
+	}
+}
+
+// Set 显式设置功能状态（如探测结果）
+func (c *Capabilities) Set(feature Feature, supported bool, reason string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	state := CapabilityUnsupported
+	if supported {
+		state = CapabilitySupported
+	}
+	c.entries[feature] = capabilityEntry{state: state, reason: reason}
+}
+
+// Reset 清除全部判定依据
+func (c *Capabilities) Reset() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.version, c.hasVersion = Version{}, false
+	c.funcs = make(map[string]string)
+	c.entries = make(map[Feature]capabilityEntry)
+}
+
+// 判定功能状态（需持有读锁）
+func (c *Capabilities) state(feature Feature) (CapabilityState, string) {
+	if entry, ok := c.entries[feature]; ok {
+		return entry.state, entry.reason
+	}
+	req, ok := FeatureRequirements[feature]
+	if !ok {
+		return CapabilityUnknown, ""
+	}
+	state := CapabilityUnknown
+	// 版本
+	if req.MinVersion != "" && c.hasVersion {
+		if minVersion, ok := ParseVersion(req.MinVersion); ok {
+			if !c.version.Satisfies(minVersion) {
+				return CapabilityUnsupported, fmt.Sprintf("requires version %s, device %s", req.MinVersion, c.version.Raw)
+			}
+			state = CapabilitySupported
+		}
+	}
+	// 通道功能
+	if len(req.FuncKeys) > 0 && len(c.funcs) > 0 {
+		found, enabled := false, false
+		for _, key := range req.FuncKeys {
+			for name, val := range c.funcs {
+				if strings.EqualFold(name, key) {
+					found = true
+					enabled = enabled || (val != "0" && !strings.EqualFold(val, "false"))
+				}
+			}
+		}
+		if found && !enabled {
+			return CapabilityUnsupported, "disabled in channel func list"
+		}
+		if found {
+			state = CapabilitySupported
+		}
+	}
+	return state, ""
+}
+
+// State 获取功能支持状态
+func (c *Capabilities) State(feature Feature) CapabilityState {
+	if c == nil {
+		return CapabilityUnknown
+	}
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	state, _ := c.state(feature)
+	return state
+}
+
+// Available 功能是否可用（状态未知时视为可用）
+func (c *Capabilities) Available(feature Feature) bool {
+	return c.State(feature) != CapabilityUnsupported
+}
+
+// Check 检查功能是否可用
+//
+//	@param feature: 功能
+//	@return 已确认不支持时返回*UnsupportedError，否则返回nil
+func (c *Capabilities) Check(feature Feature) error {
+	if c == nil {
+		return nil
+	}
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	if state, reason := c.state(feature); state == CapabilityUnsupported {
+		return &UnsupportedError{Feature: feature, Reason: reason}
+	}
+	return nil
+}
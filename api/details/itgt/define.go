@@ -6,6 +6,7 @@
 package itgt
 
 import (
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
 	"github.com/kaicen-x/holosens-sdc-sdk/api/details/itgt/target"
 	"github.com/kaicen-x/holosens-sdc-sdk/pkg/httpconn"
 )
@@ -28,3 +29,8 @@ func NewManager(connInstance *httpconn.Connect) *Manager {
 func (m *Manager) TargetManager() *target.Manager {
 	return m.targetManager
 }
+
+// SetCapabilities 设置设备能力（传递给各子管理器）
+func (m *Manager) SetCapabilities(capabilities *common.Capabilities) {
+	m.targetManager.SetCapabilities(capabilities)
+}
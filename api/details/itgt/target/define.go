@@ -6,6 +6,7 @@
 package target
 
 import (
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
 	"github.com/kaicen-x/holosens-sdc-sdk/api/details/itgt/target/recognize"
 	"github.com/kaicen-x/holosens-sdc-sdk/pkg/httpconn"
 )
@@ -28,3 +29,8 @@ func NewManager(connInstance *httpconn.Connect) *Manager {
 func (p *Manager) RecognizeManager() *recognize.Manager {
 	return p.recognizeManager
 }
+
+// SetCapabilities 设置设备能力（传递给各子管理器）
+func (p *Manager) SetCapabilities(capabilities *common.Capabilities) {
+	p.recognizeManager.SetCapabilities(capabilities)
+}
@@ -0,0 +1,212 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口目标识别属性枚举
+ */
+package recognize
+
+import (
+	"github.com/kaicen-x/holosens-sdc-sdk/pkg/enumlabel"
+)
+
+// 目标识别属性会作为请求参数发送给设备，因此JSON序列化保持为数字；
+// 展示或对外输出时请使用Labeled获取带标签的枚举值。
+
+// Gender 性别
+type Gender int
+
+// 性别枚举
+const (
+	Gender_All     Gender = -1 // 未知（查询时表示全部）
+	Gender_Male    Gender = 0  // 男
+	Gender_Female  Gender = 1  // 女
+	Gender_Unknown Gender = 2  // 未知
+)
+
+// 性别标签表
+var genderTable = enumlabel.NewTable("gender",
+	enumlabel.Item[Gender]{Code: Gender_All, Zh: "未知（查询时表示全部）", En: "unknown (all when querying)"},
+	enumlabel.Item[Gender]{Code: Gender_Male, Zh: "男", En: "male"},
+	enumlabel.Item[Gender]{Code: Gender_Female, Zh: "女", En: "female"},
+	enumlabel.Item[Gender]{Code: Gender_Unknown, Zh: "未知", En: "unknown"},
+)
+
+// GenderItems 获取性别全部枚举项
+func GenderItems() []enumlabel.Item[Gender] {
+	return genderTable.Items()
+}
+
+// String 获取中文标签
+func (v Gender) String() string {
+	return genderTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v Gender) Label(lang enumlabel.Lang) string {
+	return genderTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v Gender) Valid() bool {
+	return genderTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v Gender) Validate() error {
+	return genderTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v Gender) Labeled() enumlabel.Value {
+	return genderTable.Value(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *Gender) UnmarshalJSON(data []byte) error {
+	code, err := genderTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
+
+// CardType 证件类型
+type CardType int
+
+// 证件类型枚举
+const (
+	CardType_All           CardType = -1 // 未知（查询时表示全部）
+	CardType_IDCard        CardType = 0  // 身份证
+	CardType_Passport      CardType = 1  // 护照
+	CardType_OfficerCard   CardType = 2  // 军官证
+	CardType_DriverLicense CardType = 3  // 驾驶证
+	CardType_Other         CardType = 4  // 其他
+)
+
+// 证件类型标签表
+var cardTypeTable = enumlabel.NewTable("cardType",
+	enumlabel.Item[CardType]{Code: CardType_All, Zh: "未知（查询时表示全部）", En: "unknown (all when querying)"},
+	enumlabel.Item[CardType]{Code: CardType_IDCard, Zh: "身份证", En: "ID card"},
+	enumlabel.Item[CardType]{Code: CardType_Passport, Zh: "护照", En: "passport"},
+	enumlabel.Item[CardType]{Code: CardType_OfficerCard, Zh: "军官证", En: "officer card"},
+	enumlabel.Item[CardType]{Code: CardType_DriverLicense, Zh: "驾驶证", En: "driver license"},
+	enumlabel.Item[CardType]{Code: CardType_Other, Zh: "其他", En: "other"},
+)
+
+// CardTypeItems 获取证件类型全部枚举项
+func CardTypeItems() []enumlabel.Item[CardType] {
+	return cardTypeTable.Items()
+}
+
+// String 获取中文标签
+func (v CardType) String() string {
+	return cardTypeTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v CardType) Label(lang enumlabel.Lang) string {
+	return cardTypeTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v CardType) Valid() bool {
+	return cardTypeTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v CardType) Validate() error {
+	return cardTypeTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v CardType) Labeled() enumlabel.Value {
+	return cardTypeTable.Value(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *CardType) UnmarshalJSON(data []byte) error {
+	code, err := cardTypeTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
+
+// FeatureStatus 特征值状态
+type FeatureStatus int
+
+// 特征值状态枚举
+const (
+	FeatureStatus_New           FeatureStatus = 0  // 新建目标库状态
+	FeatureStatus_All           FeatureStatus = 1  // 查询全部
+	FeatureStatus_Reextract     FeatureStatus = 2  // 重新提取
+	FeatureStatus_NotExtracted  FeatureStatus = 3  // 未提取
+	FeatureStatus_Extracted     FeatureStatus = 4  // 已提取
+	FeatureStatus_ExtractFailed FeatureStatus = 5  // 提取失败
+	FeatureStatus_InvalidSize   FeatureStatus = 6  // 图片尺寸不规范
+	FeatureStatus_DecodeFailed  FeatureStatus = 7  // 图片解码失败
+	FeatureStatus_DetectFailed  FeatureStatus = 8  // 目标检测失败
+	FeatureStatus_FeatureFailed FeatureStatus = 9  // 特征提取失败
+	FeatureStatus_WriteFailed   FeatureStatus = 10 // 特征写入失败
+	FeatureStatus_Blurred       FeatureStatus = 11 // 目标图片清晰度不够
+	FeatureStatus_Occluded      FeatureStatus = 12 // 目标图片遮挡较严重
+)
+
+// 特征值状态标签表
+var featureStatusTable = enumlabel.NewTable("featureStatus",
+	enumlabel.Item[FeatureStatus]{Code: FeatureStatus_New, Zh: "新建目标库状态", En: "new library"},
+	enumlabel.Item[FeatureStatus]{Code: FeatureStatus_All, Zh: "查询全部", En: "all"},
+	enumlabel.Item[FeatureStatus]{Code: FeatureStatus_Reextract, Zh: "重新提取", En: "re-extract"},
+	enumlabel.Item[FeatureStatus]{Code: FeatureStatus_NotExtracted, Zh: "未提取", En: "not extracted"},
+	enumlabel.Item[FeatureStatus]{Code: FeatureStatus_Extracted, Zh: "已提取", En: "extracted"},
+	enumlabel.Item[FeatureStatus]{Code: FeatureStatus_ExtractFailed, Zh: "提取失败", En: "extraction failed"},
+	enumlabel.Item[FeatureStatus]{Code: FeatureStatus_InvalidSize, Zh: "图片尺寸不规范", En: "invalid image size"},
+	enumlabel.Item[FeatureStatus]{Code: FeatureStatus_DecodeFailed, Zh: "图片解码失败", En: "image decoding failed"},
+	enumlabel.Item[FeatureStatus]{Code: FeatureStatus_DetectFailed, Zh: "目标检测失败", En: "target detection failed"},
+	enumlabel.Item[FeatureStatus]{Code: FeatureStatus_FeatureFailed, Zh: "特征提取失败", En: "feature extraction failed"},
+	enumlabel.Item[FeatureStatus]{Code: FeatureStatus_WriteFailed, Zh: "特征写入失败", En: "feature writing failed"},
+	enumlabel.Item[FeatureStatus]{Code: FeatureStatus_Blurred, Zh: "目标图片清晰度不够", En: "image not clear enough"},
+	enumlabel.Item[FeatureStatus]{Code: FeatureStatus_Occluded, Zh: "目标图片遮挡较严重", En: "target heavily occluded"},
+)
+
+// FeatureStatusItems 获取特征值状态全部枚举项
+func FeatureStatusItems() []enumlabel.Item[FeatureStatus] {
+	return featureStatusTable.Items()
+}
+
+// String 获取中文标签
+func (v FeatureStatus) String() string {
+	return featureStatusTable.Label(v, enumlabel.LangZh)
+}
+
+// Label 获取指定语言的标签
+func (v FeatureStatus) Label(lang enumlabel.Lang) string {
+	return featureStatusTable.Label(v, lang)
+}
+
+// Valid 是否在取值范围内
+func (v FeatureStatus) Valid() bool {
+	return featureStatusTable.Valid(v)
+}
+
+// Validate 校验取值范围
+func (v FeatureStatus) Validate() error {
+	return featureStatusTable.Validate(v)
+}
+
+// Labeled 获取带标签的枚举值
+func (v FeatureStatus) Labeled() enumlabel.Value {
+	return featureStatusTable.Value(v)
+}
+
+// UnmarshalJSON 反序列化（兼容数字、数字字符串与带标签的JSON对象）
+func (v *FeatureStatus) UnmarshalJSON(data []byte) error {
+	code, err := featureStatusTable.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*v = code
+	return nil
+}
@@ -36,6 +36,11 @@ type TargetLibChangeReply = common.Response[common.ResponseStatus]
 //	@param	params: 目标库修改参数
 //	@return 异常信息
 func (p *Manager) TargetLibChange(params TargetLibChangeParams) error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureTargetLib); err != nil {
+		return err
+	}
+
 	// 获取客户端
 	client := p.connInstance.LockHttpClient()
 	defer p.connInstance.Unlock()
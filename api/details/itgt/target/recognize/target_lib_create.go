@@ -25,6 +25,11 @@ type TargetLibCreateReply = common.Response[common.ResponseStatus]
 //	@param	params: 目标库新建参数
 //	@return 异常信息
 func (p *Manager) TargetLibCreate(params TargetLibCreateParams) error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureTargetLib); err != nil {
+		return err
+	}
+
 	// 获取客户端
 	client := p.connInstance.LockHttpClient()
 	defer p.connInstance.Unlock()
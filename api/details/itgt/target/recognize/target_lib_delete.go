@@ -32,6 +32,11 @@ type TargetLibDeleteReply = common.Response[common.ResponseStatus]
 //	@param	params：目标库删除参数（TargetLibDeleteWithName：待删除的目标库名称，不填表示删除所有目标库）
 //	@return 异常信息
 func (p *Manager) TargetLibDelete(params ...TargetLibDeleteParam) error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureTargetLib); err != nil {
+		return err
+	}
+
 	// 获取客户端
 	client := p.connInstance.LockHttpClient()
 	defer p.connInstance.Unlock()
@@ -30,6 +30,11 @@ type TargetLibQueryReply struct {
 //	@return 目标库查询响应数据
 //	@return 异常信息
 func (p *Manager) TargetLibQuery() (*TargetLibQueryReplyData, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureTargetLib); err != nil {
+		return nil, err
+	}
+
 	// 获取客户端
 	client := p.connInstance.LockHttpClient()
 	defer p.connInstance.Unlock()
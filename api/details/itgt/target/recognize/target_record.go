@@ -5,6 +5,8 @@
  */
 package recognize
 
+import "errors"
+
 // TargetRecordParamsWithLib 目标记录参数关联的目标库信息
 type TargetRecordParamsWithLib struct {
 	// 目标库ID（必填）
@@ -18,7 +20,7 @@ type TargetRecordBaseInfo struct {
 	Name string `json:"name"`
 	// 性别（选填）
 	//	取值范围：0-男，1-女，2-未知，-1-未知
-	Gender int `json:"gender"`
+	Gender Gender `json:"gender"`
 	// 生日（选填）
 	//	取值范围：0~31字符，支持数字、字母、"-"以及"/"
 	Birthday string `json:"birthday"`
@@ -30,13 +32,13 @@ type TargetRecordBaseInfo struct {
 	City string `json:"city"`
 	// 证件类型（选填）
 	//	取值范围：0-身份证，1-护照，2-军官证，3-驾驶证，4-其他，-1-未知
-	CardType int `json:"cardType"`
+	CardType CardType `json:"cardType"`
 	// 证件号（选填）
 	//	取值范围：0~31字符
 	CardId string `json:"cardId"`
 	// 外部指定的目标ID（选填）
 	//	用于外部管理目标库记录
-	//	SDC 9.0.0-LG0001版本新增
+	//	SDC 9.0.0-LG0001版本新增（已确认设备不支持时，添加或修改目标记录返回common.ErrUnsupported）
 	//	取值范围：不超过64个字符，支持英文字母、数字
 	ExternalId string `json:"externalId"`
 	// 目标图片是否保存到摄像机（选填）
@@ -82,7 +84,7 @@ type TargetRecordBatchQueryInfo struct {
 	// 	10-特征写入失败
 	// 	11-目标图片清晰度不够
 	// 	12-目标图片遮挡较严重
-	FeatureStatus int `json:"featureStatus"`
+	FeatureStatus FeatureStatus `json:"featureStatus"`
 }
 
 // TargetRecordInfo 目标记录信息
@@ -109,5 +111,10 @@ type TargetRecordInfo struct {
 	// 	10-特征写入失败
 	// 	11-目标图片清晰度不够
 	// 	12-目标图片遮挡较严重
-	FeatureStatus int `json:"featureStatus"`
+	FeatureStatus FeatureStatus `json:"featureStatus"`
+}
+
+// Validate 校验目标记录基础信息中的枚举值
+func (p *TargetRecordBaseInfo) Validate() error {
+	return errors.Join(p.Gender.Validate(), p.CardType.Validate())
 }
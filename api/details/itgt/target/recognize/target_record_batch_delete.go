@@ -32,6 +32,11 @@ type TargetRecordBatchDeleteReply = common.Response[common.ResponseStatus]
 //	@param	params: 目标记录批量删除参数
 //	@return	错误信息
 func (p *Manager) TargetRecordBatchDelete(params TargetRecordBatchDeleteParams) error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureTargetLib); err != nil {
+		return err
+	}
+
 	// 获取客户端
 	client := p.connInstance.LockHttpClient()
 	defer p.connInstance.Unlock()
@@ -5,6 +5,10 @@
  */
 package recognize
 
+import (
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
+)
+
 // TargetRecordBatchQueryParams 目标记录批量查询参数
 //
 //	1、全部查询，gender=-1，cardType=-1，isStore=-1，其他字段，数字为0，字符串的为空
@@ -36,6 +40,11 @@ type TargetRecordBatchQueryReply struct {
 //	@param	params: 目标记录批量查询参数
 //	@return	错误信息
 func (p *Manager) TargetRecordBatchQuery(params TargetRecordBatchQueryParams) (*TargetRecordBatchQueryReply, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureTargetLib); err != nil {
+		return nil, err
+	}
+
 	// 获取客户端
 	client := p.connInstance.LockHttpClient()
 	defer p.connInstance.Unlock()
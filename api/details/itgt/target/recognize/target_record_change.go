@@ -71,6 +71,16 @@ func fillTargetRecordChangeFormData(formData *multipart.Writer, params *TargetRe
 //	@param	img: 目标记录图片
 //	@return	错误信息
 func (p *Manager) TargetRecordChange(params TargetRecordChangeParams, img []byte) error {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureTargetLib); err != nil {
+		return err
+	}
+	if params.Record.ExternalId != "" {
+		if err := p.capabilities.Check(common.FeatureTargetExternal); err != nil {
+			return err
+		}
+	}
+
 	// 获取客户端
 	client := p.connInstance.LockHttpClient()
 	defer p.connInstance.Unlock()
@@ -11,6 +11,8 @@ import (
 	"errors"
 	"io"
 	"mime/multipart"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
 )
 
 // TargetRecordCreateParams 目标记录添加参数
@@ -75,6 +77,16 @@ func fillTargetRecordCreateFormData(formData *multipart.Writer, params *TargetRe
 //	@return	目标记录添加响应
 //	@return	错误信息
 func (p *Manager) TargetRecordCreate(params TargetRecordCreateParams, img []byte) (*TargetRecordCreateReply, error) {
+	// 检查设备能力
+	if err := p.capabilities.Check(common.FeatureTargetLib); err != nil {
+		return nil, err
+	}
+	if params.Record.ExternalId != "" {
+		if err := p.capabilities.Check(common.FeatureTargetExternal); err != nil {
+			return nil, err
+		}
+	}
+
 	// 获取客户端
 	client := p.connInstance.LockHttpClient()
 	defer p.connInstance.Unlock()
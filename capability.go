@@ -0,0 +1,149 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口设备能力发现
+ */
+package holosenssdcsdk
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
+	"github.com/kaicen-x/holosens-sdc-sdk/pkg/httpconn"
+)
+
+var (
+	// ErrCapabilitiesNotFound：会话未提供设备能力
+	ErrCapabilitiesNotFound = errors.New("capabilities not found")
+)
+
+// CapabilitiesIface 提供设备能力的会话接口
+type CapabilitiesIface interface {
+	// 获取设备能力
+	Capabilities() *common.Capabilities
+}
+
+// HttpIface 提供HTTP连接通道的会话接口
+type HttpIface interface {
+	// 获取HTTP连接通道
+	GetHttp() *httpconn.Connect
+}
+
+// DiscoverCapabilities 发现设备能力
+//
+// 依次根据软件版本、通道功能（FuncList）确定功能支持状态；开启探测时，
+// 对仍无法确定且配置了探测接口的功能发送GET请求，返回2xx时视为支持，返回404或501时视为不支持，
+// 其他状态码（如401、403、5xx）无法说明设备能力，保持未知。
+// 发现结果写入会话的设备能力，各管理器在调用接口前据此返回common.ErrUnsupported。
+//
+//	@param cache: 会话缓存器
+//	@param key: 会话唯一标识
+//	@param probe: 是否探测接口
+//	@return 设备能力
+//	@return 异常信息
+func DiscoverCapabilities(cache *SessionCache, key string, probe bool) (*common.Capabilities, error) {
+	instance, err := cache.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	provider, ok := instance.(CapabilitiesIface)
+	if !ok {
+		return nil, ErrCapabilitiesNotFound
+	}
+	caps := provider.Capabilities()
+	caps.Reset()
+
+	// 软件版本
+	baseInfo, err := instance.DeviceManager().BaseInfoQuery(101)
+	if err != nil {
+		return nil, err
+	}
+	caps.SetVersion(baseInfo.SoftVersion)
+
+	// 通道功能（部分款型不支持通道信息查询，忽略异常）
+	if channelInfo, err := instance.DeviceManager().ChannelInfoQuery(); err == nil {
+		for _, item := range channelInfo.CnsChnParam {
+			caps.AddFuncList(item.FuncList)
+		}
+	}
+
+	// 探测接口
+	if probe {
+		if httpProvider, ok := instance.(HttpIface); ok {
+			// 按固定顺序探测，便于排查
+			features := make([]common.Feature, 0, len(common.FeatureRequirements))
+			for feature, req := range common.FeatureRequirements {
+				if req.ProbePath != "" {
+					features = append(features, feature)
+				}
+			}
+			sort.Slice(features, func(i, j int) bool { return features[i] < features[j] })
+
+			for _, feature := range features {
+				if caps.State(feature) != common.CapabilityUnknown {
+					continue
+				}
+				path := common.FeatureRequirements[feature].ProbePath
+				status, err := capabilityProbe(httpProvider.GetHttp(), path)
+				if err != nil {
+					// 连接异常时后续探测均会失败，保留已有结果
+					return caps, err
+				}
+				switch {
+				case status == http.StatusNotFound, status == http.StatusNotImplemented:
+					caps.Set(feature, false, fmt.Sprintf("probe %s status %d", path, status))
+				case status >= 200 && status < 300:
+					caps.Set(feature, true, fmt.Sprintf("probe %s status %d", path, status))
+				}
+			}
+		}
+	}
+
+	// OK
+	return caps, nil
+}
+
+// 探测接口，返回响应状态码
+func capabilityProbe(conn *httpconn.Connect, path string) (int, error) {
+	// 获取Socket连接
+	client := conn.LockHttpClient()
+	defer conn.Unlock()
+
+	// 发送请求
+	res, err := client.Get(path).
+		SetContentType("application/x-www-form-urlencoded").
+		Send()
+	if err != nil {
+		return 0, err
+	}
+	// 读完剩余数据，避免残留数据影响同一连接上的后续请求
+	io.Copy(io.Discard, res.Body)
+	res.Body.Close()
+
+	// OK
+	return res.StatusCode, nil
+}
+
+// AutoDiscoverCapabilities 会话加入时自动发现设备能力
+//
+//	@param cache: 会话缓存器
+//	@param probe: 是否探测接口
+//	@param onError: 发现失败回调（选填）
+//	@return 取消自动发现
+func AutoDiscoverCapabilities(cache *SessionCache, probe bool, onError func(key string, err error)) (cancel func()) {
+	return cache.Listen(func(event SessionCacheEvent) {
+		if event.Type != SessionCacheEventSet {
+			return
+		}
+		// 监听回调中不宜阻塞，异步查询设备
+		go func() {
+			if _, err := DiscoverCapabilities(cache, event.Key, probe); err != nil && onError != nil {
+				onError(event.Key, err)
+			}
+		}()
+	})
+}
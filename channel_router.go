@@ -0,0 +1,95 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口设备通道路由
+ */
+package holosenssdcsdk
+
+import (
+	"sync"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/application/device"
+)
+
+// ChannelRouter 设备通道路由器
+//
+// 按会话缓存设备通道拓扑，供按通道调用的接口（如抓拍、录像、元数据）确定是否需要携带通道ID。
+// 会话加入或移除时清除对应缓存，设备重新注册后重新查询拓扑。
+type ChannelRouter struct {
+	cache  *SessionCache                      // 会话缓存器
+	mtx    sync.Mutex                         // 拓扑缓存互斥锁
+	topos  map[string]*device.ChannelTopology // 拓扑缓存（键：会话唯一标识）
+	unbind func()                             // 取消会话事件监听
+}
+
+// NewChannelRouter 创建设备通道路由器
+//
+//	@param cache: 会话缓存器
+//	@return 设备通道路由器
+func NewChannelRouter(cache *SessionCache) *ChannelRouter {
+	r := &ChannelRouter{
+		cache: cache,
+		topos: make(map[string]*device.ChannelTopology),
+	}
+	r.unbind = cache.Listen(func(event SessionCacheEvent) {
+		r.mtx.Lock()
+		delete(r.topos, event.Key)
+		r.mtx.Unlock()
+	})
+	return r
+}
+
+// Close 停止监听会话事件
+func (r *ChannelRouter) Close() {
+	r.unbind()
+}
+
+// Topology 获取会话的设备通道拓扑（首次获取时查询设备）
+//
+//	@param key: 会话唯一标识
+//	@return 设备通道拓扑
+//	@return 异常信息
+func (r *ChannelRouter) Topology(key string) (*device.ChannelTopology, error) {
+	r.mtx.Lock()
+	topo, ok := r.topos[key]
+	r.mtx.Unlock()
+	if ok {
+		return topo, nil
+	}
+
+	// 查询设备
+	instance, err := r.cache.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	var base []device.ChannelBaseInfo
+	if server, ok := instance.(*SessionWithServer); ok {
+		base = server.InitiativeRegisterParams.ChannelInfoArr
+	}
+	topo, err = instance.DeviceManager().ChannelTopologyQuery(base...)
+	if err != nil {
+		return nil, err
+	}
+
+	// 缓存（查询期间会话已被替换时不缓存）
+	if current, err := r.cache.Get(key); err == nil && current == instance {
+		r.mtx.Lock()
+		r.topos[key] = topo
+		r.mtx.Unlock()
+	}
+	return topo, nil
+}
+
+// Route 获取通道调用路由
+//
+//	@param key: 会话唯一标识
+//	@param uuid: 通道UUID
+//	@return 通道调用路由
+//	@return 异常信息
+func (r *ChannelRouter) Route(key, uuid string) (device.ChannelRoute, error) {
+	topo, err := r.Topology(key)
+	if err != nil {
+		return device.ChannelRoute{}, err
+	}
+	return topo.Route(uuid)
+}
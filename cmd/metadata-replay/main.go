@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/pkg/digest"
+	"github.com/kaicen-x/holosens-sdc-sdk/pkg/httprecord"
+)
+
+// 重放时不转发的请求头（由HTTP客户端重新生成）
+var skipHeaders = map[string]bool{
+	"Authorization":     true,
+	"Content-Length":    true,
+	"Host":              true,
+	"Connection":        true,
+	"Transfer-Encoding": true,
+}
+
+// 构建重放目标地址
+//
+//	目标地址未指定路径时，使用录制时的请求URI
+func buildTargetURL(target *url.URL, record *httprecord.Record) (string, error) {
+	if target.Path != "" && target.Path != "/" {
+		return target.String(), nil
+	}
+	uri, err := url.ParseRequestURI(record.RequestURI)
+	if err != nil {
+		return "", err
+	}
+	return target.ResolveReference(uri).String(), nil
+}
+
+// 重放一条记录
+func replay(client *http.Client, target *url.URL, record *httprecord.Record) (int, error) {
+	// 构建请求
+	targetURL, err := buildTargetURL(target, record)
+	if err != nil {
+		return 0, err
+	}
+	method := record.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	req, err := http.NewRequest(method, targetURL, bytes.NewReader(record.Body))
+	if err != nil {
+		return 0, err
+	}
+	for key, values := range record.Header {
+		if skipHeaders[http.CanonicalHeaderKey(key)] {
+			continue
+		}
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	// 发送请求
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, res.Body)
+	return res.StatusCode, nil
+}
+
+// 元数据上报重放工具
+//
+// 将录制的元数据上报请求（httprecord归档）重新发送到任意接收端，用于离线复现问题
+func main() {
+	// 解析命令行参数
+	archive := flag.String("archive", "", "录制的归档文件路径（必填）")
+	target := flag.String("url", "", "接收端地址，如 https://127.0.0.1:8443 或 https://127.0.0.1:8443/metadata（必填，未指定路径时使用录制时的请求URI）")
+	speed := flag.Float64("speed", 1, "重放速度倍率：1为原始时序，2为两倍速，0为不等待尽快发送")
+	username := flag.String("user", "", "Digest认证用户名（选填）")
+	password := flag.String("pass", "", "Digest认证密码（选填）")
+	insecure := flag.Bool("insecure", false, "跳过TLS证书校验")
+	timeout := flag.Duration("timeout", time.Second*30, "单个请求超时时间")
+	flag.Parse()
+	if *archive == "" || *target == "" || *speed < 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	targetURL, err := url.Parse(*target)
+	if err != nil {
+		log.Fatalf("invalid url: %s", err)
+	}
+
+	// 打开归档
+	file, err := os.Open(*archive)
+	if err != nil {
+		log.Fatalf("open archive: %s", err)
+	}
+	defer file.Close()
+	reader, err := httprecord.NewReader(file)
+	if err != nil {
+		log.Fatalf("read archive: %s", err)
+	}
+	defer reader.Close()
+
+	// 构建HTTP客户端
+	var transport http.RoundTripper = &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: *insecure},
+	}
+	if *username != "" {
+		transport = &digest.Transport{
+			Username: *username,
+			Password: *password,
+			Base:     transport,
+		}
+	}
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   *timeout,
+	}
+
+	// 按原始时序重放
+	var firstRecord time.Time
+	startAt := time.Now()
+	total, failed := 0, 0
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			log.Fatalf("read record: %s", err)
+		}
+		// 等待到缩放后的原始发送时间
+		if firstRecord.IsZero() {
+			firstRecord = record.Time
+		}
+		if *speed > 0 {
+			offset := time.Duration(float64(record.Time.Sub(firstRecord)) / *speed)
+			if wait := time.Until(startAt.Add(offset)); wait > 0 {
+				time.Sleep(wait)
+			}
+		}
+		// 重放
+		total++
+		code, err := replay(client, targetURL, record)
+		if err != nil {
+			failed++
+			log.Printf("#%d %s %s: %s", total, record.Method, record.RequestURI, err)
+			continue
+		}
+		if code < 200 || code >= 300 {
+			failed++
+		}
+		log.Printf("#%d %s %s: %d (%d bytes)", total, record.Method, record.RequestURI, code, len(record.Body))
+	}
+
+	// 输出统计
+	fmt.Printf("replayed %d requests, %d failed, elapsed %s\n", total, failed, time.Since(startAt).Round(time.Millisecond))
+}
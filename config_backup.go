@@ -0,0 +1,336 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口设备配置备份与恢复
+ */
+package holosenssdcsdk
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/application/device"
+	"github.com/kaicen-x/holosens-sdc-sdk/api/application/metadata"
+	"github.com/kaicen-x/holosens-sdc-sdk/api/details/itgt"
+	"github.com/kaicen-x/holosens-sdc-sdk/api/details/itgt/target/recognize"
+)
+
+// ConfigBundleVersion 配置备份格式版本
+const ConfigBundleVersion = 1
+
+var (
+	// ErrConfigBundleVersion：配置备份格式版本不受支持
+	ErrConfigBundleVersion = errors.New("unsupported config bundle version")
+	// ErrItgtManagerNotFound：会话未提供智能分析管理器
+	ErrItgtManagerNotFound = errors.New("itgt manager not found")
+)
+
+// ItgtManagerIface 提供智能分析管理器的会话接口
+type ItgtManagerIface interface {
+	// 获取智能分析管理器
+	ItgtManager() *itgt.Manager
+}
+
+// ConfigBundleDevice 配置备份来源设备
+type ConfigBundleDevice struct {
+	DevType     string `json:"devType"`     // 设备款型
+	SoftVersion string `json:"softVersion"` // 软件版本
+	ESN         string `json:"ESN"`         // 设备序列号
+}
+
+// ConfigBundleChannel 配置备份通道配置
+type ConfigBundleChannel struct {
+	UUID        string `json:"UUID"`        // 通道UUID（来源设备）
+	ChannelID   string `json:"channelId"`   // 通道号（恢复时按通道号匹配替换设备的通道）
+	DeviceID    string `json:"deviceID"`    // 设备ID
+	ChannelName string `json:"channelName"` // 通道名称
+}
+
+// ConfigBundleTargetLib 配置备份目标库（仅目标库定义，不含目标记录）
+type ConfigBundleTargetLib struct {
+	recognize.TargetLibBaseInfo
+	OnControl int `json:"control"` // 目标库设防状态（0-未设防, 1-设防）
+}
+
+// ConfigBundle 配置备份（JSON格式）
+//
+// 包含SDK可读取的配置：设备ID、通道名称、智能元数据订阅、目标库定义。
+// 设备不返回订阅的认证用户名与密码，恢复前请按需补充；目标库中的目标记录（含图片）不在备份范围内。
+type ConfigBundle struct {
+	Version       int                           `json:"version"`       // 格式版本
+	CreatedAt     int64                         `json:"createdAt"`     // 备份时间（UTC时间戳，单位秒）
+	Device        ConfigBundleDevice            `json:"device"`        // 来源设备
+	Channels      []ConfigBundleChannel         `json:"channels"`      // 通道配置
+	Subscriptions []metadata.SubscribeAddParams `json:"subscriptions"` // 智能元数据订阅（会话不支持时为空）
+	TargetLibs    []ConfigBundleTargetLib       `json:"targetLibs"`    // 目标库定义（会话不支持时为空）
+}
+
+// CollectConfigBundle 采集设备配置备份
+//
+//	@param cache: 会话缓存器
+//	@param key: 会话唯一标识
+//	@return 配置备份
+//	@return 异常信息
+func CollectConfigBundle(cache *SessionCache, key string) (*ConfigBundle, error) {
+	// 获取会话
+	instance, err := cache.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	manager := instance.DeviceManager()
+
+	// 设备信息
+	baseInfo, err := manager.BaseInfoQuery(101)
+	if err != nil {
+		return nil, err
+	}
+	bundle := &ConfigBundle{
+		Version:   ConfigBundleVersion,
+		CreatedAt: time.Now().Unix(),
+		Device: ConfigBundleDevice{
+			DevType:     baseInfo.DevType,
+			SoftVersion: baseInfo.SoftVersion,
+			ESN:         baseInfo.ESN,
+		},
+	}
+
+	// 通道与设备ID
+	channels, err := manager.ChannelInfoQuery()
+	if err != nil {
+		return nil, err
+	}
+	ids, err := manager.IdQuery()
+	if err != nil {
+		return nil, err
+	}
+	deviceIDs := make(map[string]string, len(ids.IDs))
+	for _, id := range ids.IDs {
+		deviceIDs[id.UUID] = id.DeviceID
+	}
+	for _, channel := range channels.CnsChnParam {
+		item := ConfigBundleChannel{
+			UUID:      channel.Uuid,
+			ChannelID: channel.AttrList.ChannelID,
+			DeviceID:  deviceIDs[channel.Uuid],
+		}
+		names, err := manager.ChannelNameQuery(channel.Uuid)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			if name.UUID == "" || name.UUID == channel.Uuid {
+				item.ChannelName = name.ChannelName
+				break
+			}
+		}
+		bundle.Channels = append(bundle.Channels, item)
+	}
+
+	// 智能元数据订阅
+	if metadataManager, ok := instance.(MetadataManagerIface); ok {
+		subscriptions, err := metadataManager.MetadataManager().SubscribeQuery()
+		if err != nil {
+			return nil, err
+		}
+		for _, subscription := range subscriptions.Subscriptions {
+			bundle.Subscriptions = append(bundle.Subscriptions, metadata.SubscribeAddParams{
+				SubscribeBaseInfo: subscription.SubscribeBaseInfo,
+			})
+		}
+	}
+
+	// 目标库定义
+	if itgtManager, ok := instance.(ItgtManagerIface); ok {
+		libs, err := itgtManager.ItgtManager().TargetManager().RecognizeManager().TargetLibQuery()
+		if err != nil {
+			return nil, err
+		}
+		for _, lib := range libs.TargetLibs {
+			bundle.TargetLibs = append(bundle.TargetLibs, ConfigBundleTargetLib{
+				TargetLibBaseInfo: recognize.TargetLibBaseInfo{
+					Name:      lib.Name,
+					Type:      lib.Type,
+					Threshold: lib.Threshold,
+					LinkAlarm: lib.LinkAlarm,
+				},
+				OnControl: lib.OnControl,
+			})
+		}
+	}
+
+	// OK
+	return bundle, nil
+}
+
+// ConfigApplyOptions 配置恢复选项
+type ConfigApplyOptions struct {
+	// 通道映射（来源通道UUID -> 目标通道UUID，选填，未映射的通道按通道号匹配）
+	ChannelMap map[string]string
+	// 是否跳过设备ID
+	SkipDeviceIDs bool
+	// 是否跳过通道名称
+	SkipChannelNames bool
+	// 是否跳过智能元数据订阅（恢复时以备份中的订阅为准，删除目标设备上的其他订阅）
+	SkipSubscriptions bool
+	// 是否跳过目标库（恢复时按库名匹配，新建缺少的目标库并更新已有目标库的参数）
+	SkipTargetLibs bool
+}
+
+// ConfigApplyResult 配置恢复结果
+type ConfigApplyResult struct {
+	Channels      map[string]string                  // 已恢复的通道（来源通道UUID -> 目标通道UUID）
+	Unmatched     []string                           // 未匹配到目标通道的来源通道UUID
+	Subscriptions *metadata.SubscribeReconcileResult // 订阅调和结果
+	TargetLibs    int                                // 已恢复的目标库数量
+}
+
+// ApplyConfigBundle 将配置备份恢复到设备（如替换故障设备）
+//
+// 单项恢复失败不会中断，全部错误会合并返回，同时返回已完成的恢复结果。
+//
+//	@param cache: 会话缓存器
+//	@param key: 目标会话唯一标识
+//	@param bundle: 配置备份
+//	@param opts: 恢复选项
+//	@return 恢复结果
+//	@return 异常信息
+func ApplyConfigBundle(cache *SessionCache, key string, bundle *ConfigBundle, opts ConfigApplyOptions) (*ConfigApplyResult, error) {
+	if bundle.Version <= 0 || bundle.Version > ConfigBundleVersion {
+		return nil, fmt.Errorf("%w: %d", ErrConfigBundleVersion, bundle.Version)
+	}
+
+	// 获取会话
+	instance, err := cache.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	manager := instance.DeviceManager()
+
+	// 匹配目标通道
+	channels, err := manager.ChannelInfoQuery()
+	if err != nil {
+		return nil, err
+	}
+	targets := make(map[string]string, len(channels.CnsChnParam))
+	for _, channel := range channels.CnsChnParam {
+		targets[channel.AttrList.ChannelID] = channel.Uuid
+	}
+	result := &ConfigApplyResult{Channels: make(map[string]string)}
+	for _, channel := range bundle.Channels {
+		target, ok := opts.ChannelMap[channel.UUID]
+		if !ok {
+			target, ok = targets[channel.ChannelID]
+		}
+		if !ok || target == "" {
+			result.Unmatched = append(result.Unmatched, channel.UUID)
+			continue
+		}
+		result.Channels[channel.UUID] = target
+	}
+
+	// 恢复设备ID与通道名称
+	var errs []error
+	if !opts.SkipDeviceIDs {
+		var params device.IdSettingParams
+		for _, channel := range bundle.Channels {
+			if target, ok := result.Channels[channel.UUID]; ok && channel.DeviceID != "" {
+				params.IDs = append(params.IDs, device.IdInfo{UUID: target, DeviceID: channel.DeviceID})
+			}
+		}
+		if len(params.IDs) > 0 {
+			if err := manager.IdSetting(params); err != nil {
+				errs = append(errs, fmt.Errorf("device id: %w", err))
+			}
+		}
+	}
+	if !opts.SkipChannelNames {
+		for _, channel := range bundle.Channels {
+			if target, ok := result.Channels[channel.UUID]; ok && channel.ChannelName != "" {
+				err := manager.ChannelNameSetting(target, device.ChannelNameSettingParams{ChannelName: channel.ChannelName})
+				if err != nil {
+					errs = append(errs, fmt.Errorf("channel name %s: %w", target, err))
+				}
+			}
+		}
+	}
+
+	// 恢复智能元数据订阅
+	if !opts.SkipSubscriptions && len(bundle.Subscriptions) > 0 {
+		if metadataManager, ok := instance.(MetadataManagerIface); ok {
+			result.Subscriptions, err = metadataManager.MetadataManager().SubscribeReconcile(bundle.Subscriptions)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("subscriptions: %w", err))
+			}
+		} else {
+			errs = append(errs, ErrMetadataManagerNotFound)
+		}
+	}
+
+	// 恢复目标库
+	if !opts.SkipTargetLibs && len(bundle.TargetLibs) > 0 {
+		if itgtManager, ok := instance.(ItgtManagerIface); ok {
+			n, err := applyTargetLibs(itgtManager.ItgtManager().TargetManager().RecognizeManager(), bundle.TargetLibs)
+			result.TargetLibs = n
+			if err != nil {
+				errs = append(errs, fmt.Errorf("target libs: %w", err))
+			}
+		} else {
+			errs = append(errs, ErrItgtManagerNotFound)
+		}
+	}
+
+	// OK
+	return result, errors.Join(errs...)
+}
+
+// 恢复目标库定义（按库名匹配）
+func applyTargetLibs(manager *recognize.Manager, libs []ConfigBundleTargetLib) (int, error) {
+	// 新建缺少的目标库
+	current, err := manager.TargetLibQuery()
+	if err != nil {
+		return 0, err
+	}
+	exists := make(map[string]bool, len(current.TargetLibs))
+	for _, lib := range current.TargetLibs {
+		exists[lib.Name] = true
+	}
+	var errs []error
+	for _, lib := range libs {
+		if !exists[lib.Name] {
+			if err := manager.TargetLibCreate(recognize.TargetLibCreateParams{FaceLib: lib.TargetLibBaseInfo}); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", lib.Name, err))
+			}
+		}
+	}
+
+	// 重新查询目标库ID，更新参数与设防状态
+	current, err = manager.TargetLibQuery()
+	if err != nil {
+		return 0, errors.Join(append(errs, err)...)
+	}
+	ids := make(map[string]int, len(current.TargetLibs))
+	for _, lib := range current.TargetLibs {
+		ids[lib.Name] = lib.ID
+	}
+	applied := 0
+	for _, lib := range libs {
+		id, ok := ids[lib.Name]
+		if !ok {
+			continue
+		}
+		err := manager.TargetLibChange(recognize.TargetLibChangeParams{
+			FaceLib: recognize.TargetLibChangeData{
+				ID:                id,
+				OnControl:         lib.OnControl,
+				TargetLibBaseInfo: lib.TargetLibBaseInfo,
+			},
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", lib.Name, err))
+			continue
+		}
+		applied++
+	}
+	return applied, errors.Join(errs...)
+}
@@ -0,0 +1,232 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口设备密码轮换
+ */
+package holosenssdcsdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/application/device"
+)
+
+var (
+	// ErrAuthorizationNotSupported：会话不支持读取或设置认证信息
+	ErrAuthorizationNotSupported = errors.New("session authorization not supported")
+	// ErrCredentialRotateFailed：新密码验证失败（已回滚）
+	ErrCredentialRotateFailed = errors.New("credential rotation verify failed")
+	// ErrCredentialRollbackFailed：新密码验证失败且回滚失败（需人工处理）
+	ErrCredentialRollbackFailed = errors.New("credential rollback failed")
+	// ErrCredentialProbeRequired：轮换非会话认证用户的密码时未指定验证函数
+	ErrCredentialProbeRequired = errors.New("credential probe required for non-session user")
+	// ErrCredentialSessionLost：轮换后会话已不在缓存中、已被替换或未设置认证信息
+	ErrCredentialSessionLost = errors.New("credential rotation session lost")
+)
+
+// AuthorizationIface 可读取与设置认证信息的会话接口
+type AuthorizationIface interface {
+	// 获取连接认证信息
+	GetAuthorization() (username, password string)
+	// 设置连接认证信息
+	SetAuthorization(username, password string)
+}
+
+// CredentialRotateOptions 密码轮换选项
+type CredentialRotateOptions struct {
+	// 用户名（默认为会话当前认证用户）
+	UserName string
+	// 原密码（默认为会话当前认证密码，修改其他用户时必填）
+	OldPassword string
+	// 新密码（必填，按会话唯一标识生成，便于每台设备使用不同密码）
+	NewPassword func(key string) string
+	// 验证函数（默认使用会话认证信息查询设备基础信息）
+	//
+	//	默认验证函数只能验证会话当前认证用户，轮换其他用户的密码时必填，且须使用该用户的新密码完成认证
+	Probe func(instance SessionCacheIface) error
+	// 同时轮换的设备数量（批量轮换时有效，默认4）
+	Concurrency int
+}
+
+// CredentialRotateResult 密码轮换结果
+type CredentialRotateResult struct {
+	Key        string // 会话唯一标识
+	Rotated    bool   // 是否已轮换为新密码
+	RolledBack bool   // 是否已回滚为原密码
+	Error      error  // 异常信息
+}
+
+// 默认验证函数
+func credentialProbe(instance SessionCacheIface) error {
+	_, err := instance.DeviceManager().BaseInfoQuery(101)
+	return err
+}
+
+// 检查轮换后会话是否仍在缓存中、为同一实例且已设置认证信息
+func credentialSessionCheck(cache *SessionCache, key string, instance SessionCacheIface) error {
+	cur, err := cache.Get(key)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrCredentialSessionLost, err)
+	}
+	if cur != instance {
+		return fmt.Errorf("%w: session replaced", ErrCredentialSessionLost)
+	}
+	if !instance.IsSetAuthorization() {
+		return fmt.Errorf("%w: authorization not set", ErrCredentialSessionLost)
+	}
+	return nil
+}
+
+// RotateCredential 设备密码轮换
+//
+// 修改设备上的用户密码，修改的是会话当前认证用户时同步更新会话认证信息，然后执行验证；
+// 验证失败时恢复原认证信息，必要时将设备密码改回原密码。
+// 结束前检查会话仍在缓存中、为同一实例且已设置认证信息，否则返回ErrCredentialSessionLost。
+//
+//	@param cache: 会话缓存器
+//	@param key: 会话唯一标识
+//	@param opts: 密码轮换选项
+//	@return 密码轮换结果
+func RotateCredential(cache *SessionCache, key string, opts CredentialRotateOptions) CredentialRotateResult {
+	result := CredentialRotateResult{Key: key}
+	if opts.NewPassword == nil {
+		result.Error = errors.New("new password required")
+		return result
+	}
+
+	// 获取会话
+	instance, err := cache.Get(key)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	auth, ok := instance.(AuthorizationIface)
+	if !ok {
+		result.Error = ErrAuthorizationNotSupported
+		return result
+	}
+	curUser, curPass := auth.GetAuthorization()
+	if opts.UserName == "" {
+		opts.UserName = curUser
+	}
+	if opts.OldPassword == "" && opts.UserName == curUser {
+		opts.OldPassword = curPass
+	}
+	isSelf := opts.UserName == curUser
+	if opts.Probe == nil {
+		if !isSelf {
+			result.Error = ErrCredentialProbeRequired
+			return result
+		}
+		opts.Probe = credentialProbe
+	}
+	newPassword := opts.NewPassword(key)
+
+	// 修改设备密码
+	manager := instance.DeviceManager()
+	err = manager.UserPasswordChange(device.UserPasswordParams{
+		UserName:    opts.UserName,
+		OldPassword: opts.OldPassword,
+		NewPassword: newPassword,
+	})
+	if err != nil && !isConnectionLost(err) {
+		result.Error = err
+		return result
+	}
+	if isSelf {
+		auth.SetAuthorization(opts.UserName, newPassword)
+	}
+
+	// 验证新密码
+	probeErr := opts.Probe(instance)
+	if probeErr == nil {
+		result.Rotated = true
+		result.Error = credentialSessionCheck(cache, key, instance)
+		return result
+	}
+
+	// 回滚：先确认设备是否仍为原密码，否则将密码改回原密码
+	if isSelf {
+		auth.SetAuthorization(opts.UserName, opts.OldPassword)
+		if opts.Probe(instance) == nil {
+			result.RolledBack = true
+			result.Error = fmt.Errorf("%w: %v", ErrCredentialRotateFailed, probeErr)
+			if err := credentialSessionCheck(cache, key, instance); err != nil {
+				result.Error = errors.Join(result.Error, err)
+			}
+			return result
+		}
+		auth.SetAuthorization(opts.UserName, newPassword)
+	}
+	err = manager.UserPasswordChange(device.UserPasswordParams{
+		UserName:    opts.UserName,
+		OldPassword: newPassword,
+		NewPassword: opts.OldPassword,
+	})
+	if isSelf {
+		auth.SetAuthorization(opts.UserName, opts.OldPassword)
+	}
+	if err == nil {
+		err = opts.Probe(instance)
+	}
+	if err != nil {
+		result.Error = fmt.Errorf("%w: verify: %v, rollback: %v", ErrCredentialRollbackFailed, probeErr, err)
+		return result
+	}
+	result.RolledBack = true
+	result.Error = fmt.Errorf("%w: %v", ErrCredentialRotateFailed, probeErr)
+	if err := credentialSessionCheck(cache, key, instance); err != nil {
+		result.Error = errors.Join(result.Error, err)
+	}
+	return result
+}
+
+// RotateCredentials 批量设备密码轮换
+//
+//	@param ctx: 上下文（取消后不再开始新的轮换）
+//	@param cache: 会话缓存器
+//	@param keys: 会话唯一标识列表（为空时轮换会话缓存器中的全部会话）
+//	@param opts: 密码轮换选项
+//	@return 密码轮换结果（按会话唯一标识排序）
+func RotateCredentials(ctx context.Context, cache *SessionCache, keys []string, opts CredentialRotateOptions) []CredentialRotateResult {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 4
+	}
+	if len(keys) == 0 {
+		cache.Range(func(key string, _ SessionCacheIface) bool {
+			keys = append(keys, key)
+			return true
+		})
+	}
+	sort.Strings(keys)
+
+	// 并发轮换
+	results := make([]CredentialRotateResult, len(keys))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, opts.Concurrency)
+	for i, key := range keys {
+		// 取消后不再开始新的轮换（select在两者均就绪时随机选择，需先检查）
+		if err := ctx.Err(); err != nil {
+			results[i] = CredentialRotateResult{Key: key, Error: err}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			results[i] = CredentialRotateResult{Key: key, Error: ctx.Err()}
+			continue
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(i int, key string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = RotateCredential(cache, key, opts)
+		}(i, key)
+	}
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,206 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口设备维护操作
+ */
+package holosenssdcsdk
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/application/device"
+)
+
+var (
+	// ErrMaintainProbeRequired：客户端会话不会重新注册，等待设备恢复需使用WaitProbe
+	ErrMaintainProbeRequired = errors.New("client session requires probe to wait for device")
+)
+
+// MaintainHandle 设备维护操作句柄
+//
+// 基于会话缓存器事件跟踪设备下线（会话移除）与恢复（会话重新加入）。
+// 客户端会话（SessionWithClient）由调用方主动连接，设备不会重新注册，需使用WaitProbe探测设备恢复。
+type MaintainHandle struct {
+	key         string            // 会话唯一标识
+	passive     bool              // 是否为客户端会话（设备不会重新注册）
+	offline     chan struct{}     // 设备下线通知
+	done        chan struct{}     // 设备恢复通知
+	offlineOnce sync.Once         // 下线通知只关闭一次
+	doneOnce    sync.Once         // 恢复通知只关闭一次
+	instance    SessionCacheIface // 设备恢复后的会话
+	cancel      func()            // 取消监听函数
+}
+
+// 创建设备维护操作句柄并执行操作
+func newMaintainHandle(cache *SessionCache, key string, action func(manager *device.Manager) error) (*MaintainHandle, error) {
+	// 获取会话
+	instance, err := cache.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	// 执行操作前开始监听会话事件
+	_, passive := instance.(*SessionWithClient)
+	h := &MaintainHandle{
+		key:     key,
+		passive: passive,
+		offline: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	h.cancel = cache.Listen(func(event SessionCacheEvent) {
+		if event.Key != key {
+			return
+		}
+		switch event.Type {
+		case SessionCacheEventRemove:
+			h.offlineOnce.Do(func() { close(h.offline) })
+		case SessionCacheEventSet:
+			// 设备重新注册时会先移除旧会话，此处保证下线通知先于恢复通知
+			h.offlineOnce.Do(func() { close(h.offline) })
+			h.doneOnce.Do(func() {
+				h.instance = event.Instance
+				close(h.done)
+			})
+		}
+	})
+
+	// 执行操作（设备可能在响应前断开连接）
+	if err := action(instance.DeviceManager()); err != nil && !isConnectionLost(err) {
+		h.cancel()
+		return nil, err
+	}
+	return h, nil
+}
+
+// Offline 设备下线通知（通道关闭表示设备已下线）
+func (h *MaintainHandle) Offline() <-chan struct{} {
+	return h.offline
+}
+
+// Done 设备恢复通知（通道关闭表示设备已重新注册）
+func (h *MaintainHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Wait 等待设备恢复
+//
+//	@param ctx: 上下文（可设置截止时间）
+//	@return 设备重新注册后的会话
+//	@return 异常信息（超过截止时间时返回ErrDeviceNotReturned，客户端会话返回ErrMaintainProbeRequired）
+func (h *MaintainHandle) Wait(ctx context.Context) (SessionCacheIface, error) {
+	defer h.cancel()
+	if h.passive {
+		return nil, ErrMaintainProbeRequired
+	}
+	select {
+	case <-h.done:
+		return h.instance, nil
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, ErrDeviceNotReturned
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// WaitProbe 等待设备恢复（设备重新注册或探测成功）
+//
+// 探测函数在设备下线（会话移除）或探测失败过一次之后返回成功才视为设备已恢复，
+// 以免设备尚未断开时误判。客户端会话请使用该方法，并在恢复后重新建立连接。
+//
+//	@param ctx: 上下文（可设置截止时间）
+//	@param probe: 探测函数（必填，返回nil表示设备已恢复，如TCPProbe）
+//	@param interval: 探测间隔（小于等于0时默认为3秒）
+//	@return 设备重新注册后的会话（通过探测确认时为nil）
+//	@return 异常信息（超过截止时间时返回ErrDeviceNotReturned）
+func (h *MaintainHandle) WaitProbe(ctx context.Context, probe func(ctx context.Context) error, interval time.Duration) (SessionCacheIface, error) {
+	defer h.cancel()
+	if probe == nil {
+		return nil, errors.New("probe required")
+	}
+	if interval <= 0 {
+		interval = time.Second * 3
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	down, offline := false, h.offline
+	for {
+		select {
+		case <-h.done:
+			return h.instance, nil
+		case <-offline:
+			down = true
+			// 通道已关闭，不再监听
+			offline = nil
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, ErrDeviceNotReturned
+			}
+			return nil, ctx.Err()
+		case <-ticker.C:
+			probeCtx, probeCancel := context.WithTimeout(ctx, interval)
+			err := probe(probeCtx)
+			probeCancel()
+			if err != nil {
+				down = true
+			} else if down {
+				return nil, nil
+			}
+		}
+	}
+}
+
+// Close 停止跟踪（不再等待设备恢复时调用）
+func (h *MaintainHandle) Close() {
+	h.cancel()
+}
+
+// RebootDevice 设备重启
+//
+//	handle, err := RebootDevice(cache, key)
+//	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+//	defer cancel()
+//	instance, err := handle.Wait(ctx)
+//
+//	@param cache: 会话缓存器
+//	@param key: 会话唯一标识
+//	@return 设备维护操作句柄
+//	@return 异常信息
+func RebootDevice(cache *SessionCache, key string) (*MaintainHandle, error) {
+	return newMaintainHandle(cache, key, func(manager *device.Manager) error {
+		return manager.Reboot()
+	})
+}
+
+// RestoreDeviceDefault 设备恢复默认配置
+//
+// 不保留网络配置时设备通常不会重新注册，Wait将在截止时间后返回ErrDeviceNotReturned。
+//
+//	@param cache: 会话缓存器
+//	@param key: 会话唯一标识
+//	@param params: 恢复默认配置参数
+//	@return 设备维护操作句柄
+//	@return 异常信息
+func RestoreDeviceDefault(cache *SessionCache, key string, params device.RestoreDefaultParams) (*MaintainHandle, error) {
+	return newMaintainHandle(cache, key, func(manager *device.Manager) error {
+		return manager.RestoreDefault(params)
+	})
+}
+
+// ClearDeviceData 清除设备数据
+//
+// 格式化存储卡时部分设备会重启业务进程并重新注册，无需等待时请调用句柄的Close。
+//
+//	@param cache: 会话缓存器
+//	@param key: 会话唯一标识
+//	@param target: 清除目标
+//	@return 设备维护操作句柄
+//	@return 异常信息
+func ClearDeviceData(cache *SessionCache, key string, target device.ClearTarget) (*MaintainHandle, error) {
+	return newMaintainHandle(cache, key, func(manager *device.Manager) error {
+		return manager.Clear(target)
+	})
+}
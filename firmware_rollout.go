@@ -0,0 +1,446 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口设备固件分批升级
+ */
+package holosenssdcsdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/application/device"
+)
+
+var (
+	// ErrFirmwareRolloutHalted：失败率超过阈值，分批升级已暂停
+	ErrFirmwareRolloutHalted = errors.New("firmware rollout halted")
+)
+
+// FirmwareRolloutDeviceStatus 设备升级状态
+type FirmwareRolloutDeviceStatus string
+
+// 设备升级状态枚举
+const (
+	FirmwareRolloutPending   FirmwareRolloutDeviceStatus = "pending"   // 等待升级
+	FirmwareRolloutRunning   FirmwareRolloutDeviceStatus = "running"   // 升级中
+	FirmwareRolloutSucceeded FirmwareRolloutDeviceStatus = "succeeded" // 升级成功
+	FirmwareRolloutFailed    FirmwareRolloutDeviceStatus = "failed"    // 升级失败
+	FirmwareRolloutSkipped   FirmwareRolloutDeviceStatus = "skipped"   // 未参与升级（生成升级计划时无法查询设备信息）
+)
+
+// FirmwareRolloutDevice 设备升级记录
+type FirmwareRolloutDevice struct {
+	Key        string                      `json:"key"`                  // 会话唯一标识
+	DevType    string                      `json:"devType"`              // 设备款型
+	OldVersion string                      `json:"oldVersion"`           // 升级前软件版本
+	NewVersion string                      `json:"newVersion,omitempty"` // 升级后软件版本
+	Wave       int                         `json:"wave"`                 // 批次（0为金丝雀批次，未参与升级时为-1）
+	Status     FirmwareRolloutDeviceStatus `json:"status"`               // 升级状态
+	Error      string                      `json:"error,omitempty"`      // 失败原因
+	UpdatedAt  int64                       `json:"updatedAt"`            // 状态更新时间（UTC时间戳，单位秒）
+}
+
+// FirmwareRolloutState 分批升级进度（持久化）
+type FirmwareRolloutState struct {
+	Devices    []*FirmwareRolloutDevice `json:"devices"`              // 设备升级记录
+	Halted     bool                     `json:"halted"`               // 是否已暂停
+	HaltReason string                   `json:"haltReason,omitempty"` // 暂停原因
+}
+
+// FirmwareRolloutOptions 分批升级选项
+type FirmwareRolloutOptions struct {
+	// 进度文件路径（必填，控制程序中断后使用相同路径再次执行即可继续升级）
+	StateFile string
+	// 目标设备款型（与DevType或FullDeviceType比对，为空时不限款型）
+	DevTypes []string
+	// 目标软件版本（为空时不限版本）
+	FromVersions []string
+	// 升级后的软件版本（已是该版本的设备不参与升级）
+	TargetVersion string
+	// 打开升级包（每台设备调用一次，返回升级包内容与大小）
+	OpenPackage func() (io.ReadCloser, int64, error)
+	// 升级包适用款型（选填，上传前校验）
+	PackageDevType string
+	// 金丝雀批次设备数量（默认1）
+	CanarySize int
+	// 后续每批设备数量（默认10）
+	WaveSize int
+	// 每批内同时升级的设备数量（默认4）
+	Concurrency int
+	// 失败率阈值（0~1，默认0.2，金丝雀批次出现失败即暂停；单个批次或累计失败率超过阈值时暂停）
+	MaxFailureRate float64
+	// 单台设备升级选项（ExpectedVersion默认为TargetVersion）
+	Upgrade FirmwareUpgradeOptions
+	// 设备升级状态变化回调（选填）
+	OnDevice func(record FirmwareRolloutDevice)
+}
+
+// FirmwareRollout 设备固件分批升级
+//
+// 从会话缓存器中按款型、软件版本选出目标设备，先升级金丝雀批次，成功后按批次继续升级，
+// 每批结束后统计失败率，超过阈值时自动暂停。进度持久化到进度文件中。
+type FirmwareRollout struct {
+	cache *SessionCache          // 会话缓存器
+	opts  FirmwareRolloutOptions // 分批升级选项
+	mtx   sync.Mutex             // 进度互斥锁
+	state FirmwareRolloutState   // 分批升级进度
+}
+
+// NewFirmwareRollout 创建设备固件分批升级任务
+//
+//	@param cache: 会话缓存器
+//	@param opts: 分批升级选项
+//	@return 设备固件分批升级任务
+func NewFirmwareRollout(cache *SessionCache, opts FirmwareRolloutOptions) *FirmwareRollout {
+	if opts.CanarySize <= 0 {
+		opts.CanarySize = 1
+	}
+	if opts.WaveSize <= 0 {
+		opts.WaveSize = 10
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 4
+	}
+	if opts.MaxFailureRate <= 0 {
+		opts.MaxFailureRate = 0.2
+	}
+	if opts.Upgrade.ExpectedVersion == "" {
+		opts.Upgrade.ExpectedVersion = opts.TargetVersion
+	}
+	return &FirmwareRollout{cache: cache, opts: opts}
+}
+
+// State 获取分批升级进度（副本）
+func (r *FirmwareRollout) State() FirmwareRolloutState {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	state := r.state
+	state.Devices = make([]*FirmwareRolloutDevice, len(r.state.Devices))
+	for i, record := range r.state.Devices {
+		copied := *record
+		state.Devices[i] = &copied
+	}
+	return state
+}
+
+// Resume 解除暂停并将失败的设备重新加入升级（排查失败原因后调用，再次执行Run继续升级）
+func (r *FirmwareRollout) Resume() error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if err := r.load(); err != nil {
+		return err
+	}
+	for _, record := range r.state.Devices {
+		if record.Status == FirmwareRolloutFailed {
+			record.Status = FirmwareRolloutPending
+		}
+	}
+	r.state.Halted = false
+	r.state.HaltReason = ""
+	return r.save()
+}
+
+// 加载分批升级进度（进度文件不存在时忽略）
+func (r *FirmwareRollout) load() error {
+	data, err := os.ReadFile(r.opts.StateFile)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	return json.Unmarshal(data, &r.state)
+}
+
+// 保存分批升级进度（先写临时文件再重命名，避免中断时损坏）
+func (r *FirmwareRollout) save() error {
+	data, err := json.MarshalIndent(&r.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(r.opts.StateFile+".tmp", data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(r.opts.StateFile+".tmp", r.opts.StateFile)
+}
+
+// 是否匹配任一候选值（候选为空时视为匹配）
+func rolloutMatch(candidates []string, values ...string) bool {
+	if len(candidates) == 0 {
+		return true
+	}
+	for _, candidate := range candidates {
+		for _, value := range values {
+			if value != "" && strings.EqualFold(strings.TrimSpace(candidate), strings.TrimSpace(value)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// 从会话缓存器中选出目标设备并划分批次（无法查询设备信息的设备记录为未参与升级）
+func (r *FirmwareRollout) plan() {
+	var records, skipped []*FirmwareRolloutDevice
+	r.cache.Range(func(key string, instance SessionCacheIface) bool {
+		baseInfo, err := instance.DeviceManager().BaseInfoQuery(101)
+		if err != nil {
+			skipped = append(skipped, &FirmwareRolloutDevice{
+				Key:       key,
+				Wave:      -1,
+				Status:    FirmwareRolloutSkipped,
+				Error:     err.Error(),
+				UpdatedAt: time.Now().Unix(),
+			})
+			return true
+		}
+		devTypes := append([]string{baseInfo.DevType}, strings.Split(baseInfo.FullDeviceType, "@")...)
+		if !rolloutMatch(r.opts.DevTypes, devTypes...) ||
+			!rolloutMatch(r.opts.FromVersions, baseInfo.SoftVersion) ||
+			(r.opts.TargetVersion != "" && baseInfo.SoftVersion == r.opts.TargetVersion) {
+			return true
+		}
+		records = append(records, &FirmwareRolloutDevice{
+			Key:        key,
+			DevType:    baseInfo.DevType,
+			OldVersion: baseInfo.SoftVersion,
+			Status:     FirmwareRolloutPending,
+		})
+		return true
+	})
+	// 按唯一标识排序，保证批次划分稳定
+	sort.Slice(records, func(i, j int) bool { return records[i].Key < records[j].Key })
+	for i, record := range records {
+		if i >= r.opts.CanarySize {
+			record.Wave = (i-r.opts.CanarySize)/r.opts.WaveSize + 1
+		}
+	}
+	r.state = FirmwareRolloutState{Devices: append(records, skipped...)}
+}
+
+// 更新设备升级状态并保存进度
+func (r *FirmwareRollout) update(record *FirmwareRolloutDevice, status FirmwareRolloutDeviceStatus, newVersion string, err error) error {
+	r.mtx.Lock()
+	record.Status = status
+	record.NewVersion = newVersion
+	record.Error = ""
+	if err != nil {
+		record.Error = err.Error()
+	}
+	record.UpdatedAt = time.Now().Unix()
+	saveErr := r.save()
+	copied := *record
+	r.mtx.Unlock()
+	if r.opts.OnDevice != nil {
+		r.opts.OnDevice(copied)
+	}
+	return saveErr
+}
+
+// 升级单台设备
+func (r *FirmwareRollout) upgrade(ctx context.Context, record *FirmwareRolloutDevice) error {
+	// 上次中断时可能已升级完成
+	if record.Status == FirmwareRolloutRunning && r.opts.TargetVersion != "" {
+		if instance, err := r.cache.Get(record.Key); err == nil {
+			if baseInfo, err := instance.DeviceManager().BaseInfoQuery(101); err == nil && baseInfo.SoftVersion == r.opts.TargetVersion {
+				return r.update(record, FirmwareRolloutSucceeded, baseInfo.SoftVersion, nil)
+			}
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := r.update(record, FirmwareRolloutRunning, "", nil); err != nil {
+		return err
+	}
+
+	// 打开升级包并升级
+	pkg, size, err := r.opts.OpenPackage()
+	if err != nil {
+		return r.update(record, FirmwareRolloutFailed, "", err)
+	}
+	defer pkg.Close()
+	result, err := UpgradeFirmware(ctx, r.cache, record.Key, device.UpgradeParams{
+		Package: pkg,
+		Size:    size,
+		DevType: r.opts.PackageDevType,
+	}, r.opts.Upgrade)
+	if err != nil {
+		if ctx.Err() != nil {
+			// 控制程序取消，保持升级中状态以便继续时确认
+			return ctx.Err()
+		}
+		var newVersion string
+		if result != nil {
+			newVersion = result.NewVersion
+		}
+		return r.update(record, FirmwareRolloutFailed, newVersion, err)
+	}
+	return r.update(record, FirmwareRolloutSucceeded, result.NewVersion, nil)
+}
+
+// 执行单个批次
+func (r *FirmwareRollout) runWave(ctx context.Context, records []*FirmwareRolloutDevice) error {
+	var wg sync.WaitGroup
+	var errMtx sync.Mutex
+	var firstErr error
+	sem := make(chan struct{}, r.opts.Concurrency)
+launch:
+	for _, record := range records {
+		// 取消后不再开始新的升级（select在两者均就绪时随机选择，需先检查）
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			break launch
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(record *FirmwareRolloutDevice) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := r.upgrade(ctx, record); err != nil {
+				errMtx.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				errMtx.Unlock()
+			}
+		}(record)
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return firstErr
+}
+
+// Run 执行分批升级
+//
+// 首次执行时选出目标设备并划分批次，无法查询设备信息的设备以skipped状态记录在进度中（不参与升级与失败率统计）；
+// 再次执行时从进度文件继续，跳过已完成的设备。
+//
+//	@param ctx: 上下文
+//	@return 异常信息（失败率超过阈值时返回ErrFirmwareRolloutHalted）
+func (r *FirmwareRollout) Run(ctx context.Context) error {
+	if r.opts.StateFile == "" {
+		return errors.New("rollout state file required")
+	}
+	if r.opts.OpenPackage == nil {
+		return errors.New("rollout package required")
+	}
+
+	// 加载或生成升级计划
+	r.mtx.Lock()
+	r.state = FirmwareRolloutState{}
+	err := r.load()
+	var skipped []FirmwareRolloutDevice
+	if err == nil && r.state.Devices == nil {
+		r.plan()
+		err = r.save()
+		for _, record := range r.state.Devices {
+			if record.Status == FirmwareRolloutSkipped {
+				skipped = append(skipped, *record)
+			}
+		}
+	}
+	halted, haltReason := r.state.Halted, r.state.HaltReason
+	r.mtx.Unlock()
+	if err != nil {
+		return err
+	}
+	// 通知未参与升级的设备
+	if r.opts.OnDevice != nil {
+		for _, record := range skipped {
+			r.opts.OnDevice(record)
+		}
+	}
+	if halted {
+		return fmt.Errorf("%w: %s", ErrFirmwareRolloutHalted, haltReason)
+	}
+
+	// 按批次升级
+	waves := make(map[int][]*FirmwareRolloutDevice)
+	var order []int
+	for _, record := range r.state.Devices {
+		if record.Status == FirmwareRolloutSkipped {
+			continue
+		}
+		if _, ok := waves[record.Wave]; !ok {
+			order = append(order, record.Wave)
+		}
+		waves[record.Wave] = append(waves[record.Wave], record)
+	}
+	sort.Ints(order)
+	for _, wave := range order {
+		var todo []*FirmwareRolloutDevice
+		for _, record := range waves[wave] {
+			if record.Status == FirmwareRolloutPending || record.Status == FirmwareRolloutRunning {
+				todo = append(todo, record)
+			}
+		}
+		if len(todo) > 0 {
+			if err := r.runWave(ctx, todo); err != nil {
+				return err
+			}
+		}
+
+		// 统计失败率（金丝雀批次出现失败即暂停，后续批次按本批次与累计失败率判断）
+		r.mtx.Lock()
+		var done, failed, waveDone, waveFailed int
+		for _, record := range r.state.Devices {
+			if record.Wave > wave {
+				continue
+			}
+			switch record.Status {
+			case FirmwareRolloutSucceeded:
+				done++
+			case FirmwareRolloutFailed:
+				done++
+				failed++
+			default:
+				continue
+			}
+			if record.Wave == wave {
+				waveDone++
+				if record.Status == FirmwareRolloutFailed {
+					waveFailed++
+				}
+			}
+		}
+		switch {
+		case waveFailed > 0 && (wave == 0 || float64(waveFailed)/float64(waveDone) > r.opts.MaxFailureRate):
+			r.state.Halted = true
+			r.state.HaltReason = fmt.Sprintf("wave %d: %d of %d devices failed", wave, waveFailed, waveDone)
+		case failed > 0 && float64(failed)/float64(done) > r.opts.MaxFailureRate:
+			r.state.Halted = true
+			r.state.HaltReason = fmt.Sprintf("wave %d: %d of %d devices failed in total", wave, failed, done)
+		}
+		if r.state.Halted {
+			haltReason = r.state.HaltReason
+			err = r.save()
+		}
+		halted = r.state.Halted
+		r.mtx.Unlock()
+		if err != nil {
+			return err
+		}
+		if halted {
+			return fmt.Errorf("%w: %s", ErrFirmwareRolloutHalted, haltReason)
+		}
+	}
+
+	// OK
+	return nil
+}
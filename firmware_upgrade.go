@@ -0,0 +1,126 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口设备固件升级
+ */
+package holosenssdcsdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/application/device"
+)
+
+var (
+	// ErrUpgradeVersionMismatch：升级后设备软件版本与预期不一致
+	ErrUpgradeVersionMismatch = errors.New("software version mismatch after upgrade")
+)
+
+// FirmwareUpgradeOptions 固件升级选项
+type FirmwareUpgradeOptions struct {
+	// 预期升级后的软件版本（选填，为空时仅要求版本发生变化）
+	ExpectedVersion string
+	// 等待升级完成并重新注册的超时时间（默认15分钟，不含上传耗时）
+	Timeout time.Duration
+	// 升级状态轮询间隔（默认2秒）
+	PollInterval time.Duration
+	// 升级进度回调（选填）
+	OnProgress func(status device.UpgradeStatus)
+}
+
+// FirmwareUpgradeResult 固件升级结果
+type FirmwareUpgradeResult struct {
+	Instance    SessionCacheIface     // 设备重新注册后的会话（设备未重启时为原会话）
+	OldVersion  string                // 升级前软件版本
+	NewVersion  string                // 升级后软件版本
+	Rebooted    bool                  // 设备是否重启
+	Elapsed     time.Duration         // 升级总耗时
+	FinalStatus *device.UpgradeStatus // 最后一次查询到的升级状态
+}
+
+// UpgradeFirmware 设备固件升级
+//
+// 校验并上传升级包，轮询升级进度；设备升级完成重启后等待其重新注册到会话缓存器，
+// 并通过设备基础信息确认新的软件版本。
+//
+//	@param ctx: 上下文
+//	@param cache: 会话缓存器
+//	@param key: 会话唯一标识
+//	@param params: 升级参数
+//	@param opts: 升级选项
+//	@return 升级结果
+//	@return 异常信息
+func UpgradeFirmware(ctx context.Context, cache *SessionCache, key string, params device.UpgradeParams, opts FirmwareUpgradeOptions) (*FirmwareUpgradeResult, error) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = time.Minute * 15
+	}
+
+	// 获取会话
+	instance, err := cache.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	manager := instance.DeviceManager()
+
+	// 校验升级包并记录升级前版本
+	baseInfo, err := manager.UpgradeCheck(params)
+	if err != nil {
+		return nil, err
+	}
+	result := &FirmwareUpgradeResult{Instance: instance, OldVersion: baseInfo.SoftVersion}
+
+	// 上传前开始监听重新注册事件
+	registered, cancel := cache.WatchSet(key)
+	defer cancel()
+	begin := time.Now()
+	// 升级包上传不受上下文控制，上传前确认未取消
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := manager.Upgrade(params); err != nil {
+		return nil, err
+	}
+
+	// 轮询升级进度
+	ctx, ctxCancel := context.WithTimeout(ctx, opts.Timeout)
+	defer ctxCancel()
+	status, rebooted, err := manager.UpgradeWait(ctx, opts.PollInterval, opts.OnProgress)
+	result.FinalStatus = status
+	if err != nil {
+		return result, err
+	}
+
+	// 等待设备重启后重新注册
+	if rebooted {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return result, ErrDeviceNotReturned
+			}
+			return result, ctx.Err()
+		case result.Instance = <-registered:
+			result.Rebooted = true
+		}
+	}
+
+	// 确认新版本
+	baseInfo, err = result.Instance.DeviceManager().BaseInfoQuery(101)
+	if err != nil {
+		return result, err
+	}
+	result.NewVersion = baseInfo.SoftVersion
+	result.Elapsed = time.Since(begin)
+	if opts.ExpectedVersion != "" {
+		if result.NewVersion != opts.ExpectedVersion {
+			return result, fmt.Errorf("%w: expected %s, got %s", ErrUpgradeVersionMismatch, opts.ExpectedVersion, result.NewVersion)
+		}
+	} else if result.NewVersion == result.OldVersion {
+		return result, fmt.Errorf("%w: version unchanged (%s)", ErrUpgradeVersionMismatch, result.NewVersion)
+	}
+
+	// OK
+	return result, nil
+}
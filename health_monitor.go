@@ -0,0 +1,339 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口设备资源健康监控
+ */
+package holosenssdcsdk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/application/device"
+	"github.com/kaicen-x/holosens-sdc-sdk/pkg/ringbuf"
+)
+
+// HealthMetric 健康监控指标
+type HealthMetric int
+
+// 健康监控指标枚举
+const (
+	HealthMetricCpu      HealthMetric = iota // CPU占用率（%）
+	HealthMetricMem                          // 操作系统内存占用率（%）
+	HealthMetricMmz                          // MMZ内存占用率（%）
+	HealthMetricFlash                        // Flash占用率（%）
+	HealthMetricRecvRate                     // 网络接收速率（bit/s）
+	HealthMetricSendRate                     // 网络发送速率（bit/s）
+)
+
+// String 指标名称
+func (m HealthMetric) String() string {
+	switch m {
+	case HealthMetricCpu:
+		return "cpu"
+	case HealthMetricMem:
+		return "mem"
+	case HealthMetricMmz:
+		return "mmz"
+	case HealthMetricFlash:
+		return "flash"
+	case HealthMetricRecvRate:
+		return "recvRate"
+	case HealthMetricSendRate:
+		return "sendRate"
+	}
+	return fmt.Sprintf("metric(%d)", int(m))
+}
+
+// HealthSample 健康采样
+type HealthSample struct {
+	Time          time.Time // 采样时间
+	CpuOccupyRate float64   // CPU占用率（%）
+	MemUsedRate   float64   // 操作系统内存占用率（%，不含Buff与Cache）
+	MmzUsedRate   float64   // MMZ内存占用率（%）
+	FlashUsedRate float64   // Flash占用率（%）
+	RecvRate      int       // 网络接收速率（bit/s）
+	SendRate      int       // 网络发送速率（bit/s）
+}
+
+// 计算占用率（总量为0时返回0）
+func healthUsedRate(total, free int) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(total-free) * 100 / float64(total)
+}
+
+// NewHealthSample 从设备基础信息生成健康采样
+//
+//	@param t: 采样时间
+//	@param info: 设备基础信息
+//	@return 健康采样
+func NewHealthSample(t time.Time, info *device.BaseInfoQueryReply) HealthSample {
+	mem := info.MemInfo.OsMemInfo
+	return HealthSample{
+		Time:          t,
+		CpuOccupyRate: info.CpuOccupyRate,
+		MemUsedRate:   healthUsedRate(mem.TotalMem, mem.FreeMem+mem.BuffMem+mem.CacheMem),
+		MmzUsedRate:   healthUsedRate(info.MmzInfo.TotalMem, info.MmzInfo.FreeMem),
+		FlashUsedRate: healthUsedRate(info.FlashInfo.TotalFlashSize, info.FlashInfo.FreeFlashSize),
+		RecvRate:      info.NetRateInfo.RecvRate,
+		SendRate:      info.NetRateInfo.SendRate,
+	}
+}
+
+// Value 获取指标值
+func (s *HealthSample) Value(metric HealthMetric) float64 {
+	switch metric {
+	case HealthMetricCpu:
+		return s.CpuOccupyRate
+	case HealthMetricMem:
+		return s.MemUsedRate
+	case HealthMetricMmz:
+		return s.MmzUsedRate
+	case HealthMetricFlash:
+		return s.FlashUsedRate
+	case HealthMetricRecvRate:
+		return float64(s.RecvRate)
+	case HealthMetricSendRate:
+		return float64(s.SendRate)
+	}
+	return 0
+}
+
+// HealthRule 健康阈值规则
+type HealthRule struct {
+	// 规则名称（唯一）
+	Name string
+	// 监控指标
+	Metric HealthMetric
+	// 阈值（指标值大于阈值时视为异常）
+	Threshold float64
+	// 持续时间（异常持续达到该时间后告警，0表示立即告警）
+	Duration time.Duration
+}
+
+// DefaultHealthRules 默认健康阈值规则
+var DefaultHealthRules = []HealthRule{
+	{Name: "cpu-high", Metric: HealthMetricCpu, Threshold: 90, Duration: time.Minute * 5},
+	{Name: "mem-high", Metric: HealthMetricMem, Threshold: 90, Duration: time.Minute * 5},
+	{Name: "flash-full", Metric: HealthMetricFlash, Threshold: 95},
+}
+
+// HealthAlert 健康告警
+type HealthAlert struct {
+	Rule     HealthRule // 触发的规则
+	Key      string     // 会话唯一标识
+	Value    float64    // 当前指标值
+	Since    time.Time  // 异常开始时间
+	Time     time.Time  // 告警（或恢复）时间
+	Resolved bool       // 是否为恢复通知
+}
+
+// HealthMonitorOptions 健康监控选项
+type HealthMonitorOptions struct {
+	// 采样间隔（默认30秒）
+	Interval time.Duration
+	// 每个会话保留的采样数量（默认120）
+	Capacity int
+	// 阈值规则（为nil时使用DefaultHealthRules）
+	Rules []HealthRule
+	// 告警回调（选填，异常达到持续时间时告警，恢复正常时发送恢复通知）
+	OnAlert func(alert HealthAlert)
+}
+
+// 规则状态
+type healthRuleState struct {
+	since  time.Time // 异常开始时间（零值表示正常）
+	firing bool      // 是否已告警
+}
+
+// 会话健康数据
+type healthSeries struct {
+	samples *ringbuf.Ring[HealthSample] // 采样时间序列
+	rules   map[string]*healthRuleState // 规则状态
+}
+
+// HealthMonitor 设备资源健康监控
+//
+// 按采样间隔查询会话缓存器中每个会话的设备基础信息，保存最近的采样时间序列，
+// 并按阈值规则判断异常、发送告警。会话移除时清除其健康数据。
+type HealthMonitor struct {
+	cache  *SessionCache            // 会话缓存器
+	opts   HealthMonitorOptions     // 健康监控选项
+	mtx    sync.Mutex               // 健康数据互斥锁
+	series map[string]*healthSeries // 健康数据（键：会话唯一标识）
+	cancel context.CancelFunc       // 停止监控
+	unbind func()                   // 取消会话事件监听
+	wg     sync.WaitGroup           // 等待监控结束
+}
+
+// NewHealthMonitor 创建并启动设备资源健康监控
+//
+//	@param cache: 会话缓存器
+//	@param opts: 健康监控选项
+//	@return 设备资源健康监控
+func NewHealthMonitor(cache *SessionCache, opts HealthMonitorOptions) *HealthMonitor {
+	if opts.Interval <= 0 {
+		opts.Interval = time.Second * 30
+	}
+	if opts.Capacity <= 0 {
+		opts.Capacity = 120
+	}
+	if opts.Rules == nil {
+		opts.Rules = DefaultHealthRules
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &HealthMonitor{
+		cache:  cache,
+		opts:   opts,
+		series: make(map[string]*healthSeries),
+		cancel: cancel,
+	}
+	m.unbind = cache.Listen(func(event SessionCacheEvent) {
+		if event.Type == SessionCacheEventRemove {
+			m.mtx.Lock()
+			delete(m.series, event.Key)
+			m.mtx.Unlock()
+		}
+	})
+	m.wg.Add(1)
+	go m.run(ctx)
+	return m
+}
+
+// Close 停止监控
+func (m *HealthMonitor) Close() {
+	m.cancel()
+	m.unbind()
+	m.wg.Wait()
+}
+
+// Series 获取会话的采样时间序列（从旧到新）
+func (m *HealthMonitor) Series(key string) []HealthSample {
+	m.mtx.Lock()
+	series, ok := m.series[key]
+	m.mtx.Unlock()
+	if !ok {
+		return nil
+	}
+	return series.samples.Snapshot()
+}
+
+// Latest 获取会话最新的采样
+func (m *HealthMonitor) Latest(key string) (HealthSample, bool) {
+	m.mtx.Lock()
+	series, ok := m.series[key]
+	m.mtx.Unlock()
+	if !ok {
+		return HealthSample{}, false
+	}
+	return series.samples.Last()
+}
+
+// 监控循环
+func (m *HealthMonitor) run(ctx context.Context) {
+	defer m.wg.Done()
+	ticker := time.NewTicker(m.opts.Interval)
+	defer ticker.Stop()
+	for {
+		m.sampleAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// 采样全部会话
+func (m *HealthMonitor) sampleAll(ctx context.Context) {
+	var wg sync.WaitGroup
+	m.cache.Range(func(key string, instance SessionCacheIface) bool {
+		if ctx.Err() != nil {
+			return false
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// 查询失败时跳过（设备离线由会话缓存器心跳处理）
+			info, err := instance.DeviceManager().BaseInfoQuery(101)
+			if err != nil {
+				m.miss(key)
+				return
+			}
+			m.record(key, NewHealthSample(time.Now(), info))
+		}()
+		return true
+	})
+	wg.Wait()
+}
+
+// 记录采样缺失（缺失期间无法确认异常持续，重新计算异常开始时间）
+func (m *HealthMonitor) miss(key string) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if series, ok := m.series[key]; ok {
+		for _, state := range series.rules {
+			// 已告警的规则保留异常开始时间，恢复通知中使用
+			if !state.firing {
+				state.since = time.Time{}
+			}
+		}
+	}
+}
+
+// 记录采样并判断规则
+func (m *HealthMonitor) record(key string, sample HealthSample) {
+	m.mtx.Lock()
+	series, ok := m.series[key]
+	if !ok {
+		// 采样期间会话已移除时丢弃
+		if _, err := m.cache.Get(key); err != nil {
+			m.mtx.Unlock()
+			return
+		}
+		series = &healthSeries{
+			samples: ringbuf.New[HealthSample](m.opts.Capacity),
+			rules:   make(map[string]*healthRuleState),
+		}
+		m.series[key] = series
+	}
+	series.samples.Push(sample)
+
+	// 判断规则
+	var alerts []HealthAlert
+	for _, rule := range m.opts.Rules {
+		state, ok := series.rules[rule.Name]
+		if !ok {
+			state = &healthRuleState{}
+			series.rules[rule.Name] = state
+		}
+		value := sample.Value(rule.Metric)
+		if value > rule.Threshold {
+			if state.since.IsZero() {
+				state.since = sample.Time
+			}
+			if !state.firing && sample.Time.Sub(state.since) >= rule.Duration {
+				state.firing = true
+				alerts = append(alerts, HealthAlert{Rule: rule, Key: key, Value: value, Since: state.since, Time: sample.Time})
+			}
+			continue
+		}
+		if state.firing {
+			alerts = append(alerts, HealthAlert{Rule: rule, Key: key, Value: value, Since: state.since, Time: sample.Time, Resolved: true})
+		}
+		state.since = time.Time{}
+		state.firing = false
+	}
+	m.mtx.Unlock()
+
+	// 解锁后回调
+	if m.opts.OnAlert != nil {
+		for _, alert := range alerts {
+			m.opts.OnAlert(alert)
+		}
+	}
+}
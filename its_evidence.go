@@ -0,0 +1,527 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口ITS违章证据包构建
+ */
+package holosenssdcsdk
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/application/device"
+	"github.com/kaicen-x/holosens-sdc-sdk/api/application/snapshot"
+	"github.com/kaicen-x/holosens-sdc-sdk/api/application/storage"
+)
+
+var (
+	// ErrStorageManagerNotFound：会话未提供录像回放与下载管理器
+	ErrStorageManagerNotFound = errors.New("storage manager not found")
+	// ErrEvidenceTampered：证据包内容与哈希清单不一致
+	ErrEvidenceTampered = errors.New("evidence package tampered")
+)
+
+// 证据包内的固定文件
+const (
+	itsEvidenceInfoFile     = "evidence.json"        // 证据信息
+	itsEvidenceManifestFile = "manifest.sha256"      // 哈希清单
+	itsEvidenceHmacFile     = "manifest.sha256.hmac" // 哈希清单签名
+)
+
+// StorageManagerIface 提供录像回放与下载管理器的会话接口
+type StorageManagerIface interface {
+	// 获取录像回放与下载管理器
+	StorageManager() *storage.Manager
+}
+
+// ItsEvidenceRequest ITS违章证据包构建请求
+type ItsEvidenceRequest struct {
+	// 会话唯一标识
+	Key string
+	// 通道UUID
+	UUID string
+	// 通道ID（选填，用于查询设备信息，默认101）
+	ChannelID int
+	// 违章抓拍图记录（来自抓拍图查询结果）
+	Record snapshot.SnapshotRecord
+	// 关联抓拍图查询时间范围（选填，查询违章抓拍时间前后该范围内相同车道与违章类型的抓拍图，0表示仅包含违章抓拍图）
+	RelatedWindow time.Duration
+	// 无关联录像信息时的录像截取范围（选填，截取违章抓拍时间前后该范围的录像，默认10秒）
+	ClipPadding time.Duration
+	// 是否必须包含录像（默认录像下载失败时仅记录警告）
+	RequireRecording bool
+	// 哈希清单签名密钥（选填，填写时使用HMAC-SHA256对哈希清单签名）
+	HmacKey []byte
+}
+
+// ItsEvidenceDevice 证据包设备身份信息
+type ItsEvidenceDevice struct {
+	Key            string `json:"key"`            // 会话唯一标识
+	ESN            string `json:"esn"`            // 设备序列号
+	BarCode        string `json:"barCode"`        // 设备条形码
+	DevType        string `json:"devType"`        // 设备款型
+	FullDeviceType string `json:"fullDeviceType"` // 完整设备型号
+	Manufacturer   string `json:"manufacturer"`   // 厂商名称
+	SoftVersion    string `json:"softVersion"`    // 软件版本
+	HardVersion    string `json:"hardVersion"`    // 硬件版本
+}
+
+// ItsEvidenceFile 证据包文件
+type ItsEvidenceFile struct {
+	Path   string `json:"path"`   // 包内路径
+	Size   int64  `json:"size"`   // 文件大小（单位：字节）
+	SHA256 string `json:"sha256"` // SHA-256哈希值（十六进制）
+}
+
+// ItsEvidenceInfo 证据信息（写入证据包的evidence.json）
+type ItsEvidenceInfo struct {
+	Device                ItsEvidenceDevice                      `json:"device"`                // 设备身份信息
+	UUID                  string                                 `json:"uuid"`                  // 通道UUID
+	SnapTime              int64                                  `json:"snapTime"`              // 违章抓拍时间（单位秒）
+	TimeType              int                                    `json:"timeType"`              // 抓拍时间类型（0-摄像机本地时间，1-UTC时间）
+	LaneId                int                                    `json:"laneId"`                // 车道号
+	VehicleType           snapshot.SnapshotVehicleType           `json:"vehicleType"`           // 车辆类型
+	VehicleRegulationType snapshot.SnapshotVehicleRegulationType `json:"vehicleRegulationType"` // 违章类型
+	RecordStartTime       int64                                  `json:"recordStartTime"`       // 录像开始时间（单位秒，无录像时为0）
+	RecordEndTime         int64                                  `json:"recordEndTime"`         // 录像结束时间（单位秒，无录像时为0）
+	Images                []snapshot.SnapshotRecord              `json:"images"`                // 包含的抓拍图记录
+	Files                 []ItsEvidenceFile                      `json:"files"`                 // 证据文件（不含本文件与哈希清单）
+	Warnings              []string                               `json:"warnings,omitempty"`    // 构建警告
+	CreatedAt             time.Time                              `json:"createdAt"`             // 证据包创建时间
+}
+
+// ItsEvidenceResult ITS违章证据包构建结果
+type ItsEvidenceResult struct {
+	Info           ItsEvidenceInfo // 证据信息
+	ManifestSHA256 string          // 哈希清单的SHA-256哈希值（可单独留存，用于证明证据包未被替换）
+}
+
+// 计算哈希的写入器
+type hashingWriter struct {
+	w    io.Writer // 底层写入器
+	hash hash.Hash // 哈希计算器
+	size int64     // 写入字节数
+}
+
+// Write 写入数据
+func (h *hashingWriter) Write(p []byte) (int, error) {
+	n, err := h.w.Write(p)
+	h.hash.Write(p[:n])
+	h.size += int64(n)
+	return n, err
+}
+
+// 证据包写入器
+type itsEvidenceWriter struct {
+	zip   *zip.Writer       // ZIP写入器
+	files []ItsEvidenceFile // 已写入的文件
+}
+
+// 写入一个文件
+func (e *itsEvidenceWriter) create(name string, method uint16, fn func(w io.Writer) error) error {
+	header := &zip.FileHeader{
+		Name:     name,
+		Method:   method,
+		Modified: time.Now(),
+	}
+	w, err := e.zip.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	hw := &hashingWriter{w: w, hash: sha256.New()}
+	if err := fn(hw); err != nil {
+		return err
+	}
+	e.files = append(e.files, ItsEvidenceFile{
+		Path:   name,
+		Size:   hw.size,
+		SHA256: hex.EncodeToString(hw.hash.Sum(nil)),
+	})
+	return nil
+}
+
+// 构建哈希清单（sha256sum格式，按路径排序）
+func buildItsEvidenceManifest(files []ItsEvidenceFile) []byte {
+	sorted := append([]ItsEvidenceFile(nil), files...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Path < sorted[j].Path })
+	var buf bytes.Buffer
+	for _, file := range sorted {
+		fmt.Fprintf(&buf, "%s  %s\n", file.SHA256, file.Path)
+	}
+	return buf.Bytes()
+}
+
+// 查询关联抓拍图
+func itsEvidenceImages(manager *snapshot.Manager, req *ItsEvidenceRequest) ([]snapshot.SnapshotRecord, error) {
+	images := []snapshot.SnapshotRecord{req.Record}
+	if req.RelatedWindow <= 0 {
+		return images, nil
+	}
+	window := int64(req.RelatedWindow / time.Second)
+	laneId := req.Record.LaneId
+	regulationType := req.Record.VehicleRegulationType
+	snapshotType := snapshot.SnapshotType(snapshot.SnapshotType_ITS)
+	cursor := manager.NewQueryCursor(snapshot.QueryFilter{
+		UUID:                  req.UUID,
+		BeginTime:             req.Record.SnapTime - window,
+		EndTime:               req.Record.SnapTime + window,
+		LocalTime:             req.Record.TimeType == 0,
+		SnapshotType:          &snapshotType,
+		LaneId:                &laneId,
+		VehicleRegulationType: &regulationType,
+	})
+	for cursor.Next() {
+		record := cursor.Record()
+		if record.ContentId != req.Record.ContentId {
+			images = append(images, record)
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(images, func(i, j int) bool { return images[i].SnapTime < images[j].SnapTime })
+	return images, nil
+}
+
+// 获取录像截取时间窗口（UTC时间戳）
+//
+// 未返回关联录像时按抓拍时间前后截取；抓拍时间为摄像机本地时间时按设备时区转换为UTC时间，
+// 设备时区查询失败时按UTC时间截取并返回警告。
+func itsEvidenceClipWindow(manager *device.Manager, req *ItsEvidenceRequest) (int64, int64, string) {
+	info := req.Record.RecordInfo
+	if info.RecordExist && info.EndTime > info.StartTime {
+		return info.StartTime, info.EndTime, ""
+	}
+	padding := req.ClipPadding
+	if padding <= 0 {
+		padding = time.Second * 10
+	}
+	seconds := int64(padding / time.Second)
+	snapTime, warning := req.Record.SnapTime, ""
+	if req.Record.TimeType == 0 {
+		offset, err := itsEvidenceLocalOffset(manager, snapTime)
+		if err != nil {
+			warning = "device time zone unavailable, clip window assumes UTC: " + err.Error()
+		} else {
+			snapTime -= int64(offset / time.Second)
+		}
+	}
+	return snapTime - seconds, snapTime + seconds, warning
+}
+
+// 获取摄像机本地时间对应的时区偏移
+func itsEvidenceLocalOffset(manager *device.Manager, local int64) (time.Duration, error) {
+	tz, err := manager.TimeZoneQuery()
+	if err != nil {
+		return 0, err
+	}
+	return tz.OffsetAt(time.Unix(local, 0).UTC())
+}
+
+// 录像文件扩展名
+func itsEvidenceClipExt(contentType string) string {
+	switch {
+	case strings.Contains(contentType, "mp4"):
+		return ".mp4"
+	case strings.Contains(contentType, "mp2t"):
+		return ".ts"
+	case strings.Contains(contentType, "ps"):
+		return ".ps"
+	}
+	return ".bin"
+}
+
+// 下载录像到临时文件（返回的文件已定位到开头）
+func downloadItsEvidenceClip(manager *storage.Manager, uuid string, begin, end int64) (*os.File, string, error) {
+	file, err := os.CreateTemp("", "its-evidence-*.clip")
+	if err != nil {
+		return nil, "", err
+	}
+	reply, err := manager.RecordDownload(storage.RecordDownloadParams{
+		UUID:      uuid,
+		StartTime: begin,
+		EndTime:   end,
+	}, file)
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, "", err
+	}
+	return file, reply.ContentType, nil
+}
+
+// BuildItsEvidence 构建ITS违章证据包
+//
+// 证据包为ZIP格式，包含：
+//
+//	images/       违章抓拍图及关联抓拍图
+//	video/        关联录像（录像下载失败且未要求必须包含录像时不存在）
+//	evidence.json 证据信息（设备身份、车道、车辆类型、违章类型、文件列表等）
+//	manifest.sha256       全部文件的SHA-256哈希清单（sha256sum格式，可使用sha256sum -c校验）
+//	manifest.sha256.hmac  哈希清单的HMAC-SHA256签名（设置签名密钥时存在）
+//
+//	@param cache: 会话缓存器
+//	@param req: 构建请求
+//	@param w: 证据包输出
+//	@return 构建结果
+//	@return 异常信息
+func BuildItsEvidence(cache *SessionCache, req ItsEvidenceRequest, w io.Writer) (*ItsEvidenceResult, error) {
+	// 获取会话与管理器
+	instance, err := cache.Get(req.Key)
+	if err != nil {
+		return nil, err
+	}
+	snapshotProvider, ok := instance.(SnapshotManagerIface)
+	if !ok {
+		return nil, ErrSnapshotManagerNotFound
+	}
+	storageProvider, ok := instance.(StorageManagerIface)
+	if !ok {
+		return nil, ErrStorageManagerNotFound
+	}
+	snapshotManager := snapshotProvider.SnapshotManager()
+	storageManager := storageProvider.StorageManager()
+	if req.ChannelID <= 0 {
+		req.ChannelID = 101
+	}
+
+	// 设备身份
+	baseInfo, err := instance.DeviceManager().BaseInfoQuery(req.ChannelID)
+	if err != nil {
+		return nil, err
+	}
+	info := ItsEvidenceInfo{
+		Device:                newItsEvidenceDevice(req.Key, baseInfo),
+		UUID:                  req.UUID,
+		SnapTime:              req.Record.SnapTime,
+		TimeType:              req.Record.TimeType,
+		LaneId:                req.Record.LaneId,
+		VehicleType:           req.Record.VehicleType,
+		VehicleRegulationType: req.Record.VehicleRegulationType,
+		CreatedAt:             time.Now().UTC(),
+	}
+
+	// 抓拍图
+	images, err := itsEvidenceImages(snapshotManager, &req)
+	if err != nil {
+		return nil, err
+	}
+	info.Images = images
+
+	// 写入证据文件
+	bw := bufio.NewWriter(w)
+	writer := &itsEvidenceWriter{zip: zip.NewWriter(bw)}
+	for i, image := range images {
+		// 先下载到内存，以便根据图片格式确定文件扩展名
+		var buf bytes.Buffer
+		reply, err := snapshotManager.ImageDownload(snapshot.ImageDownloadParams{
+			UUID:        req.UUID,
+			ContentId:   image.ContentId,
+			ContentSize: image.ContentSize,
+		}, &buf)
+		if err != nil {
+			return nil, fmt.Errorf("download image %s: %w", image.ContentId, err)
+		}
+		contentType := reply.ContentType
+		if mediatype, _, _ := mime.ParseMediaType(contentType); mediatype == "" || mediatype == "application/octet-stream" {
+			contentType = http.DetectContentType(buf.Bytes())
+		}
+		name := path.Join("images", fmt.Sprintf("%02d_%s%s", i+1, sanitizeExportPath(image.ContentId), exportFileExt(contentType)))
+		if err := writer.create(name, zip.Store, func(w io.Writer) error {
+			_, err := buf.WriteTo(w)
+			return err
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	// 录像（先下载到临时文件，以便根据录像格式确定文件扩展名）
+	clipBegin, clipEnd, warning := itsEvidenceClipWindow(instance.DeviceManager(), &req)
+	if warning != "" {
+		info.Warnings = append(info.Warnings, warning)
+	}
+	clipFile, clipType, err := downloadItsEvidenceClip(storageManager, req.UUID, clipBegin, clipEnd)
+	switch {
+	case err == nil:
+		defer os.Remove(clipFile.Name())
+		defer clipFile.Close()
+		clipName := path.Join("video", fmt.Sprintf("%d-%d%s", clipBegin, clipEnd, itsEvidenceClipExt(clipType)))
+		if err := writer.create(clipName, zip.Store, func(w io.Writer) error {
+			_, err := io.Copy(w, clipFile)
+			return err
+		}); err != nil {
+			return nil, err
+		}
+		info.RecordStartTime, info.RecordEndTime = clipBegin, clipEnd
+	case req.RequireRecording:
+		return nil, fmt.Errorf("download recording: %w", err)
+	default:
+		info.Warnings = append(info.Warnings, "recording unavailable: "+err.Error())
+	}
+
+	// 证据信息
+	info.Files = append([]ItsEvidenceFile(nil), writer.files...)
+	infoData, err := json.MarshalIndent(&info, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writer.create(itsEvidenceInfoFile, zip.Deflate, func(w io.Writer) error {
+		_, err := w.Write(infoData)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	// 哈希清单与签名
+	manifest := buildItsEvidenceManifest(writer.files)
+	manifestHash := sha256.Sum256(manifest)
+	if err := writer.create(itsEvidenceManifestFile, zip.Deflate, func(w io.Writer) error {
+		_, err := w.Write(manifest)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	if len(req.HmacKey) > 0 {
+		mac := hmac.New(sha256.New, req.HmacKey)
+		mac.Write(manifest)
+		if err := writer.create(itsEvidenceHmacFile, zip.Deflate, func(w io.Writer) error {
+			_, err := io.WriteString(w, hex.EncodeToString(mac.Sum(nil))+"\n")
+			return err
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.zip.Close(); err != nil {
+		return nil, err
+	}
+	if err := bw.Flush(); err != nil {
+		return nil, err
+	}
+
+	// OK
+	return &ItsEvidenceResult{
+		Info:           info,
+		ManifestSHA256: hex.EncodeToString(manifestHash[:]),
+	}, nil
+}
+
+// 构建证据包设备身份信息
+func newItsEvidenceDevice(key string, baseInfo *device.BaseInfoQueryReply) ItsEvidenceDevice {
+	return ItsEvidenceDevice{
+		Key:            key,
+		ESN:            baseInfo.ESN,
+		BarCode:        baseInfo.BarCode,
+		DevType:        baseInfo.DevType,
+		FullDeviceType: baseInfo.FullDeviceType,
+		Manufacturer:   baseInfo.Manufacturer,
+		SoftVersion:    baseInfo.SoftVersion,
+		HardVersion:    baseInfo.HardVersion,
+	}
+}
+
+// VerifyItsEvidence 校验ITS违章证据包
+//
+//	@param r: 证据包
+//	@param size: 证据包大小
+//	@param hmacKey: 哈希清单签名密钥（选填，填写时同时校验签名）
+//	@return 哈希清单的SHA-256哈希值
+//	@return 异常信息（内容被篡改时返回包装了ErrEvidenceTampered的异常）
+func VerifyItsEvidence(r io.ReaderAt, size int64, hmacKey []byte) (string, error) {
+	reader, err := zip.NewReader(r, size)
+	if err != nil {
+		return "", err
+	}
+	files := make(map[string]*zip.File, len(reader.File))
+	for _, file := range reader.File {
+		files[file.Name] = file
+	}
+	readAll := func(name string) ([]byte, error) {
+		file, ok := files[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: missing %s", ErrEvidenceTampered, name)
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+
+	// 哈希清单签名
+	manifest, err := readAll(itsEvidenceManifestFile)
+	if err != nil {
+		return "", err
+	}
+	if len(hmacKey) > 0 {
+		sig, err := readAll(itsEvidenceHmacFile)
+		if err != nil {
+			return "", err
+		}
+		expected, err := hex.DecodeString(strings.TrimSpace(string(sig)))
+		if err != nil {
+			return "", fmt.Errorf("%w: invalid signature", ErrEvidenceTampered)
+		}
+		mac := hmac.New(sha256.New, hmacKey)
+		mac.Write(manifest)
+		if !hmac.Equal(mac.Sum(nil), expected) {
+			return "", fmt.Errorf("%w: signature mismatch", ErrEvidenceTampered)
+		}
+	}
+
+	// 逐个校验文件哈希
+	listed := make(map[string]bool)
+	for _, line := range strings.Split(strings.TrimSpace(string(manifest)), "\n") {
+		sum, name, ok := strings.Cut(line, "  ")
+		if !ok {
+			return "", fmt.Errorf("%w: invalid manifest line", ErrEvidenceTampered)
+		}
+		file, ok := files[name]
+		if !ok {
+			return "", fmt.Errorf("%w: missing %s", ErrEvidenceTampered, name)
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return "", err
+		}
+		h := sha256.New()
+		_, err = io.Copy(h, rc)
+		rc.Close()
+		if err != nil {
+			return "", err
+		}
+		if hex.EncodeToString(h.Sum(nil)) != sum {
+			return "", fmt.Errorf("%w: %s hash mismatch", ErrEvidenceTampered, name)
+		}
+		listed[name] = true
+	}
+	// 不允许存在清单外的文件
+	for name := range files {
+		if !listed[name] && name != itsEvidenceManifestFile && name != itsEvidenceHmacFile {
+			return "", fmt.Errorf("%w: unlisted file %s", ErrEvidenceTampered, name)
+		}
+	}
+
+	// OK
+	sum := sha256.Sum256(manifest)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,257 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口智能元数据订阅调和器
+ */
+package holosenssdcsdk
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/application/metadata"
+)
+
+var (
+	// ErrMetadataManagerNotFound：会话未提供智能元数据对接管理器
+	ErrMetadataManagerNotFound = errors.New("metadata manager not found")
+)
+
+// MetadataManagerIface 提供智能元数据对接管理器的会话接口
+type MetadataManagerIface interface {
+	// 获取智能元数据对接管理器
+	MetadataManager() *metadata.Manager
+}
+
+// MetadataSubscribeDesiredFunc 期望订阅集合提供函数
+//
+//	@param key: 会话唯一标识
+//	@param instance: 会话实例
+//	@return 该设备期望的订阅集合
+type MetadataSubscribeDesiredFunc func(key string, instance SessionCacheIface) []metadata.SubscribeAddParams
+
+// MetadataSubscribeResultFunc 订阅调和结果回调函数
+type MetadataSubscribeResultFunc func(key string, result *metadata.SubscribeReconcileResult, err error)
+
+// MetadataSubscribeReconciler 智能元数据订阅调和器
+//
+// 为会话缓存器中的每个会话维护期望的订阅集合：
+//
+//	1、会话加入（设备注册或重新注册）时立即调和；
+//	2、存在有时效的订阅时，在最短剩余时间过半时重新调和以刷新订阅；
+//	3、调和失败时按重试间隔重新调和；
+//	4、会话移除时停止该会话的调和。
+type MetadataSubscribeReconciler struct {
+	cache         *SessionCache                // 会话缓存器
+	desired       MetadataSubscribeDesiredFunc // 期望订阅集合提供函数
+	onResult      MetadataSubscribeResultFunc  // 调和结果回调
+	retryInterval time.Duration                // 调和失败重试间隔
+	minRefresh    time.Duration                // 最短刷新间隔
+
+	mtx          sync.Mutex                                   // 互斥锁
+	workers      map[string]*metadataSubscribeReconcileWorker // 调和任务
+	cancelListen func()                                       // 取消会话缓存事件监听
+}
+
+// 单个会话的调和任务
+type metadataSubscribeReconcileWorker struct {
+	instance SessionCacheIface  // 会话实例
+	trigger  chan struct{}      // 立即调和信号
+	cancel   context.CancelFunc // 任务取消器
+}
+
+// NewMetadataSubscribeReconciler 创建智能元数据订阅调和器
+//
+//	@param cache: 会话缓存器
+//	@param desired: 期望订阅集合提供函数
+//	@return 智能元数据订阅调和器
+func NewMetadataSubscribeReconciler(cache *SessionCache, desired MetadataSubscribeDesiredFunc) *MetadataSubscribeReconciler {
+	return &MetadataSubscribeReconciler{
+		cache:         cache,
+		desired:       desired,
+		retryInterval: time.Second * 30,
+		minRefresh:    time.Second * 10,
+		workers:       make(map[string]*metadataSubscribeReconcileWorker),
+	}
+}
+
+// OnResult 设置调和结果回调（请在Start之前调用）
+func (r *MetadataSubscribeReconciler) OnResult(callback MetadataSubscribeResultFunc) *MetadataSubscribeReconciler {
+	r.onResult = callback
+	return r
+}
+
+// SetRetryInterval 设置调和失败重试间隔（请在Start之前调用）
+func (r *MetadataSubscribeReconciler) SetRetryInterval(interval time.Duration) *MetadataSubscribeReconciler {
+	r.retryInterval = interval
+	return r
+}
+
+// Start 启动调和器
+//
+// 启动后立即调和会话缓存器中已有的会话，并监听后续的会话加入与移除事件
+func (r *MetadataSubscribeReconciler) Start() {
+	r.mtx.Lock()
+	if r.cancelListen != nil {
+		r.mtx.Unlock()
+		return
+	}
+	// 监听会话缓存事件
+	r.cancelListen = r.cache.Listen(func(event SessionCacheEvent) {
+		switch event.Type {
+		case SessionCacheEventSet:
+			r.startWorker(event.Key, event.Instance)
+		case SessionCacheEventRemove:
+			r.stopWorker(event.Key, event.Instance)
+		}
+	})
+	r.mtx.Unlock()
+
+	// 调和已有会话
+	r.cache.Range(func(key string, instance SessionCacheIface) bool {
+		r.startWorker(key, instance)
+		return true
+	})
+}
+
+// Trigger 立即重新调和指定会话（如期望订阅集合发生变化时）
+func (r *MetadataSubscribeReconciler) Trigger(key string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if worker, ok := r.workers[key]; ok {
+		select {
+		case worker.trigger <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// TriggerAll 立即重新调和全部会话
+func (r *MetadataSubscribeReconciler) TriggerAll() {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	for _, worker := range r.workers {
+		select {
+		case worker.trigger <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Close 停止调和器（不会删除设备上已有的订阅）
+func (r *MetadataSubscribeReconciler) Close() {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	// 取消事件监听
+	if r.cancelListen != nil {
+		r.cancelListen()
+		r.cancelListen = nil
+	}
+	// 停止全部调和任务
+	for key, worker := range r.workers {
+		worker.cancel()
+		delete(r.workers, key)
+	}
+}
+
+// 启动会话的调和任务（已存在时替换）
+func (r *MetadataSubscribeReconciler) startWorker(key string, instance SessionCacheIface) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	// 调和器已停止
+	if r.cancelListen == nil {
+		return
+	}
+	// 同一会话无需重复启动
+	if worker, ok := r.workers[key]; ok {
+		if worker.instance == instance {
+			return
+		}
+		worker.cancel()
+	}
+	// 创建调和任务
+	ctx, cancel := context.WithCancel(context.Background())
+	worker := &metadataSubscribeReconcileWorker{
+		instance: instance,
+		trigger:  make(chan struct{}, 1),
+		cancel:   cancel,
+	}
+	r.workers[key] = worker
+	// 启动调和任务
+	go r.run(ctx, key, worker)
+}
+
+// 停止会话的调和任务
+func (r *MetadataSubscribeReconciler) stopWorker(key string, instance SessionCacheIface) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	// 仅停止对应会话实例的任务，避免误停重新注册的新会话
+	if worker, ok := r.workers[key]; ok && worker.instance == instance {
+		worker.cancel()
+		delete(r.workers, key)
+	}
+}
+
+// 执行会话的调和任务
+func (r *MetadataSubscribeReconciler) run(ctx context.Context, key string, worker *metadataSubscribeReconcileWorker) {
+	for {
+		// 执行调和
+		wait := r.reconcile(key, worker.instance)
+		// 等待下一次调和
+		var timer <-chan time.Time
+		if wait > 0 {
+			timer = time.After(wait)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-worker.trigger:
+		case <-timer:
+		}
+	}
+}
+
+// 执行一次调和
+//
+//	@return 距离下一次调和的等待时长，0表示仅在触发时调和
+func (r *MetadataSubscribeReconciler) reconcile(key string, instance SessionCacheIface) time.Duration {
+	// 获取期望订阅集合
+	desired := r.desired(key, instance)
+
+	// 获取智能元数据对接管理器并执行调和
+	var result *metadata.SubscribeReconcileResult
+	var err error
+	if manager, ok := instance.(MetadataManagerIface); ok {
+		result, err = manager.MetadataManager().SubscribeReconcile(desired)
+	} else {
+		err = ErrMetadataManagerNotFound
+	}
+
+	// 回调调和结果
+	if r.onResult != nil {
+		r.onResult(key, result, err)
+	}
+
+	// 失败时按重试间隔重新调和
+	if err != nil {
+		return r.retryInterval
+	}
+
+	// 存在有时效的订阅时，在最短剩余时间过半时刷新
+	var wait time.Duration
+	for _, item := range desired {
+		if item.TimeOut <= 0 {
+			continue
+		}
+		refresh := time.Duration(item.TimeOut) * time.Second / 2
+		if wait == 0 || refresh < wait {
+			wait = refresh
+		}
+	}
+	if wait > 0 && wait < r.minRefresh {
+		wait = r.minRefresh
+	}
+	return wait
+}
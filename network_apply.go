@@ -0,0 +1,134 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口设备网络配置安全下发
+ */
+package holosenssdcsdk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"time"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/application/device"
+)
+
+var (
+	// ErrDeviceNotReturned：修改网络配置后设备未在超时时间内恢复连接
+	ErrDeviceNotReturned = errors.New("device did not come back after network change")
+)
+
+// SafeApplyOptions 网络配置安全下发选项
+type SafeApplyOptions struct {
+	// 等待设备恢复的超时时间（默认2分钟）
+	Timeout time.Duration
+	// 探测函数（选填，返回nil表示设备已恢复，如TCPProbe探测新地址）
+	Probe func(ctx context.Context) error
+	// 探测间隔（默认3秒）
+	ProbeInterval time.Duration
+}
+
+// SafeApplyResult 网络配置安全下发结果
+type SafeApplyResult struct {
+	Instance   SessionCacheIface // 设备重新注册后的会话（通过探测确认时为nil）
+	Registered bool              // 是否通过重新注册确认
+	Elapsed    time.Duration     // 设备恢复耗时
+}
+
+// TCPProbe 创建TCP连通性探测函数
+//
+//	@param addr: 探测地址（如：192.168.1.20:443）
+//	@return 探测函数
+func TCPProbe(addr string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
+
+// 是否为连接中断导致的异常（配置可能已生效）
+func isConnectionLost(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, net.ErrClosed)
+}
+
+// SafeApplyNetwork 安全下发网络配置
+//
+// 执行网络配置修改（如IP地址、主动注册平台地址），并确认设备恢复连接：
+// 设备重新注册到会话缓存器，或探测函数返回成功。
+// 修改地址后设备可能在响应前断开连接，此时视为配置可能已生效并继续等待。
+//
+//	result, err := SafeApplyNetwork(ctx, cache, key, func(m *device.Manager) error {
+//		return m.NetworkInterfaceSetting(params)
+//	}, SafeApplyOptions{Probe: TCPProbe("192.168.1.20:443")})
+//
+//	@param ctx: 上下文
+//	@param cache: 会话缓存器
+//	@param key: 会话唯一标识
+//	@param apply: 配置修改函数
+//	@param opts: 下发选项
+//	@return 下发结果
+//	@return 异常信息（设备未恢复时返回ErrDeviceNotReturned）
+func SafeApplyNetwork(ctx context.Context, cache *SessionCache, key string, apply func(manager *device.Manager) error, opts SafeApplyOptions) (*SafeApplyResult, error) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = time.Minute * 2
+	}
+	if opts.ProbeInterval <= 0 {
+		opts.ProbeInterval = time.Second * 3
+	}
+
+	// 获取会话
+	instance, err := cache.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	// 执行修改前开始监听重新注册事件
+	registered, cancel := cache.WatchSet(key)
+	defer cancel()
+	begin := time.Now()
+	if err := apply(instance.DeviceManager()); err != nil && !isConnectionLost(err) {
+		return nil, err
+	}
+
+	// 等待设备恢复
+	ctx, ctxCancel := context.WithTimeout(ctx, opts.Timeout)
+	defer ctxCancel()
+	var probeTick <-chan time.Time
+	if opts.Probe != nil {
+		ticker := time.NewTicker(opts.ProbeInterval)
+		defer ticker.Stop()
+		probeTick = ticker.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, ErrDeviceNotReturned
+			}
+			return nil, ctx.Err()
+		case newInstance := <-registered:
+			return &SafeApplyResult{
+				Instance:   newInstance,
+				Registered: true,
+				Elapsed:    time.Since(begin),
+			}, nil
+		case <-probeTick:
+			probeCtx, probeCancel := context.WithTimeout(ctx, opts.ProbeInterval)
+			err := opts.Probe(probeCtx)
+			probeCancel()
+			if err == nil {
+				return &SafeApplyResult{Elapsed: time.Since(begin)}, nil
+			}
+		}
+	}
+}
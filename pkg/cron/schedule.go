@@ -0,0 +1,209 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule 调度计划
+type Schedule interface {
+	// Next 获取指定时间之后的下一次触发时间（不存在时返回零值）
+	Next(t time.Time) time.Time
+}
+
+// 字段取值范围
+type fieldBounds struct {
+	name     string // 字段名称
+	min, max int    // 取值范围
+}
+
+var (
+	minuteBounds = fieldBounds{"minute", 0, 59}
+	hourBounds   = fieldBounds{"hour", 0, 23}
+	domBounds    = fieldBounds{"day of month", 1, 31}
+	monthBounds  = fieldBounds{"month", 1, 12}
+	dowBounds    = fieldBounds{"day of week", 0, 7}
+)
+
+// 预定义调度计划
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse 解析调度计划
+//
+// 支持标准5字段格式（分 时 日 月 周，支持 * , - / 语法，周日可写为0或7）、
+// 预定义计划（@yearly、@monthly、@weekly、@daily、@hourly）
+// 以及固定间隔（@every 30s，间隔为Go时间格式）。
+//
+//	@param spec: 调度计划表达式
+//	@return 调度计划
+//	@return 异常信息
+func Parse(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	// 固定间隔
+	if strings.HasPrefix(spec, "@every ") {
+		interval, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil {
+			return nil, err
+		}
+		if interval < time.Second {
+			return nil, errors.New("cron: interval must be at least 1s")
+		}
+		return every(interval), nil
+	}
+	// 预定义计划
+	if strings.HasPrefix(spec, "@") {
+		expanded, ok := descriptors[spec]
+		if !ok {
+			return nil, fmt.Errorf("cron: unknown descriptor %s", spec)
+		}
+		spec = expanded
+	}
+	// 标准5字段格式
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron: expected 5 fields, got %d", len(fields))
+	}
+	s := &specSchedule{}
+	var err error
+	if s.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, err
+	}
+	if s.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, err
+	}
+	if s.dom, err = parseField(fields[2], domBounds); err != nil {
+		return nil, err
+	}
+	if s.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, err
+	}
+	if s.dow, err = parseField(fields[4], dowBounds); err != nil {
+		return nil, err
+	}
+	// 周日：7与0等价
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domStar = fields[2] == "*" || fields[2] == "?"
+	s.dowStar = fields[4] == "*" || fields[4] == "?"
+	return s, nil
+}
+
+// MustParse 解析调度计划（解析失败时panic，适用于常量表达式）
+func MustParse(spec string) Schedule {
+	s, err := Parse(spec)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// 解析单个字段为位图
+func parseField(field string, bounds fieldBounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		// 步长
+		step := 1
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			val, err := strconv.Atoi(part[idx+1:])
+			if err != nil || val <= 0 {
+				return 0, fmt.Errorf("cron: invalid step in %s field: %s", bounds.name, part)
+			}
+			step = val
+			part = part[:idx]
+		}
+		// 范围
+		begin, end := bounds.min, bounds.max
+		switch {
+		case part == "*" || part == "?":
+		case strings.Contains(part, "-"):
+			idx := strings.Index(part, "-")
+			var err1, err2 error
+			begin, err1 = strconv.Atoi(part[:idx])
+			end, err2 = strconv.Atoi(part[idx+1:])
+			if err1 != nil || err2 != nil {
+				return 0, fmt.Errorf("cron: invalid range in %s field: %s", bounds.name, part)
+			}
+		default:
+			val, err := strconv.Atoi(part)
+			if err != nil {
+				return 0, fmt.Errorf("cron: invalid value in %s field: %s", bounds.name, part)
+			}
+			begin = val
+			// 单个值带步长时表示从该值开始到最大值
+			if step == 1 {
+				end = val
+			}
+		}
+		if begin < bounds.min || end > bounds.max || begin > end {
+			return 0, fmt.Errorf("cron: %s field out of range: %s", bounds.name, part)
+		}
+		for i := begin; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+	return bits, nil
+}
+
+// 固定间隔调度计划
+type every time.Duration
+
+// Next 获取下一次触发时间（按间隔对齐到整秒）
+func (e every) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(e)).Truncate(time.Second)
+}
+
+// 标准5字段调度计划
+type specSchedule struct {
+	minute, hour, dom, month, dow uint64 // 各字段位图
+	domStar, dowStar              bool   // 日、周字段是否为任意值
+}
+
+// 日期是否匹配（日与周字段均有限定时，满足任一即可，与标准cron一致）
+func (s *specSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// Next 获取下一次触发时间（使用t所在的时区计算）
+func (s *specSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Add(time.Minute - time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+	// 最多向后查找5年，避免不可能的表达式（如2月30日）导致死循环
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
@@ -0,0 +1,77 @@
+package digest
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+)
+
+// Transport 支持Digest认证的HTTP传输层
+//
+// 收到401响应且携带Digest认证质询时，自动构建认证摘要并重新发送请求
+type Transport struct {
+	Username string            // 认证用户名
+	Password string            // 认证密码
+	Base     http.RoundTripper // 底层传输层（选填，默认http.DefaultTransport）
+}
+
+// 获取底层传输层
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// RoundTrip 执行请求
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// 缓存请求体，以便认证后重新发送
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		data, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = data
+	}
+	newBody := func() io.ReadCloser {
+		if body == nil {
+			return http.NoBody
+		}
+		return io.NopCloser(bytes.NewReader(body))
+	}
+
+	// 首次发送请求
+	first := req.Clone(req.Context())
+	first.Body = newBody()
+	res, err := t.base().RoundTrip(first)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusUnauthorized || res.Header.Get("WWW-Authenticate") == "" {
+		return res, nil
+	}
+
+	// 解析认证质询（未指定算法时默认为MD5）
+	realm, nonce, algorithm := ParseDigestWwwAuthenticate(res.Header)
+	if algorithm == "" {
+		algorithm = "MD5"
+	}
+	authorization := MakeDigestAuthorization(
+		req.Method, req.URL.RequestURI(),
+		realm, nonce, algorithm,
+		t.Username, t.Password,
+	)
+	if authorization == "" {
+		return res, nil
+	}
+	io.Copy(io.Discard, res.Body)
+	res.Body.Close()
+
+	// 携带认证摘要重新发送请求
+	second := req.Clone(req.Context())
+	second.Body = newBody()
+	second.Header.Set("Authorization", authorization)
+	return t.base().RoundTrip(second)
+}
@@ -0,0 +1,671 @@
+package diskqueue
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrQueueFull：队列已达到最大容量
+	ErrQueueFull = errors.New("disk queue is full")
+	// ErrQueueClosed：队列已关闭
+	ErrQueueClosed = errors.New("disk queue is closed")
+	// ErrRecordTooLarge：单条记录超出段文件大小
+	ErrRecordTooLarge = errors.New("record is larger than segment size")
+)
+
+const (
+	segmentExt       = ".seg"       // 段文件扩展名
+	checkpointFile   = "checkpoint" // 检查点文件名
+	recordHeaderSize = 16           // 记录头长度：4字节数据长度 + 4字节CRC32 + 8字节写入时间（UnixNano）
+)
+
+// Options 磁盘队列配置
+type Options struct {
+	Dir         string        // 队列目录（必填）
+	SegmentSize int64         // 单个段文件最大字节数（选填，默认64MB）
+	MaxSize     int64         // 队列最大字节数（选填，0表示不限制，不能小于SegmentSize），超出时Append返回ErrQueueFull
+	MaxAge      time.Duration // 记录最长保留时间（选填，0表示不限制），超龄的段文件即使未确认也会被删除
+	NoSync      bool          // 写入后不执行fsync（选填，提升性能但掉电时可能丢失最近写入的记录）
+}
+
+// Message 队列消息
+type Message struct {
+	Seq       uint64    // 序号（从1开始单调递增）
+	Timestamp time.Time // 写入时间
+	Data      []byte    // 消息数据
+}
+
+// Stats 队列统计信息
+type Stats struct {
+	Segments     int       // 段文件数量
+	Bytes        int64     // 段文件总字节数
+	Pending      uint64    // 未确认的消息数（积压量）
+	Unread       uint64    // 未读取的消息数
+	OldestUnack  time.Time // 最早未确认消息的写入时间（无积压时为零值）
+	Appended     uint64    // 本次打开后写入的消息数
+	Acked        uint64    // 本次打开后确认的消息数
+	Dropped      uint64    // 本次打开后因超龄被丢弃的未确认消息数
+	NextSeq      uint64    // 下一条消息的序号
+	CheckpointAt uint64    // 已确认的最大序号
+}
+
+// 段文件
+type segment struct {
+	firstSeq  uint64    // 第一条记录序号
+	count     uint64    // 记录数
+	size      int64     // 文件大小
+	firstTime time.Time // 第一条记录写入时间
+	lastTime  time.Time // 最后一条记录写入时间
+	path      string    // 文件路径
+}
+
+// 段文件最后一条记录序号
+func (s *segment) lastSeq() uint64 {
+	return s.firstSeq + s.count - 1
+}
+
+// 检查点
+type checkpoint struct {
+	Acked uint64 `json:"acked"` // 已确认的最大序号
+}
+
+// Queue 基于段文件的预写式磁盘队列
+//
+// 消息写入后立即持久化到段文件，消费者读取消息并在处理完成后确认（累计确认），确认位置持久化到检查点文件。
+// 重新打开队列后，从检查点之后重新投递，即至少一次（at-least-once）语义。
+type Queue struct {
+	opts Options // 配置
+
+	mtx      sync.Mutex    // 互斥锁
+	closed   bool          // 是否已关闭
+	segments []*segment    // 段文件列表（按序号升序）
+	writer   *os.File      // 当前写入的段文件
+	nextSeq  uint64        // 下一条消息序号
+	ackSeq   uint64        // 已确认的最大序号
+	notify   chan struct{} // 新消息通知（关闭即广播）
+
+	readSeq    uint64   // 下一条待读取的序号
+	reader     *os.File // 当前读取的段文件
+	readerSeg  uint64   // 当前读取的段文件第一条记录序号
+	readerSeq  uint64   // 读取位置对应的序号
+	readerOffs int64    // 读取位置
+
+	appended uint64 // 统计：写入数
+	acked    uint64 // 统计：确认数
+	dropped  uint64 // 统计：丢弃数
+}
+
+// Open 打开磁盘队列（目录不存在时自动创建）
+func Open(opts Options) (*Queue, error) {
+	// 检查配置
+	if opts.Dir == "" {
+		return nil, errors.New("disk queue dir is empty")
+	}
+	if opts.SegmentSize <= 0 {
+		opts.SegmentSize = 64 * 1024 * 1024
+	}
+	if opts.MaxSize > 0 && opts.MaxSize < opts.SegmentSize {
+		return nil, fmt.Errorf("disk queue max size %d is less than segment size %d", opts.MaxSize, opts.SegmentSize)
+	}
+	// 创建目录
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	// 创建队列
+	q := &Queue{
+		opts:   opts,
+		notify: make(chan struct{}),
+	}
+	// 读取检查点
+	if err := q.loadCheckpoint(); err != nil {
+		return nil, err
+	}
+	// 加载段文件
+	if err := q.loadSegments(); err != nil {
+		return nil, err
+	}
+	// 检查点缺失或落后于第一个段文件时（如段文件已被删除），从第一个段文件开始读取
+	if len(q.segments) > 0 && q.segments[0].firstSeq > q.ackSeq+1 {
+		q.ackSeq = q.segments[0].firstSeq - 1
+	}
+	// 计算下一条消息序号
+	q.nextSeq = q.ackSeq + 1
+	if n := len(q.segments); n > 0 {
+		if last := q.segments[n-1]; last.firstSeq+last.count > q.nextSeq {
+			q.nextSeq = last.firstSeq + last.count
+		}
+	}
+	q.readSeq = q.ackSeq + 1
+	// 清理已确认或超龄的段文件
+	q.cleanup(time.Now())
+	// OK
+	return q, nil
+}
+
+// 读取检查点
+func (q *Queue) loadCheckpoint() error {
+	data, err := os.ReadFile(filepath.Join(q.opts.Dir, checkpointFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var cp checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return fmt.Errorf("invalid checkpoint: %w", err)
+	}
+	q.ackSeq = cp.Acked
+	return nil
+}
+
+// 保存检查点（先写临时文件再重命名，保证原子性）
+func (q *Queue) saveCheckpoint() error {
+	data, err := json.Marshal(checkpoint{Acked: q.ackSeq})
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(q.opts.Dir, checkpointFile+".tmp")
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if !q.opts.NoSync {
+		if err := file.Sync(); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(q.opts.Dir, checkpointFile))
+}
+
+// 加载段文件
+func (q *Queue) loadSegments() error {
+	entries, err := os.ReadDir(q.opts.Dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, segmentExt) {
+			continue
+		}
+		firstSeq, err := strconv.ParseUint(strings.TrimSuffix(name, segmentExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		q.segments = append(q.segments, &segment{
+			firstSeq: firstSeq,
+			path:     filepath.Join(q.opts.Dir, name),
+		})
+	}
+	sort.Slice(q.segments, func(i, j int) bool {
+		return q.segments[i].firstSeq < q.segments[j].firstSeq
+	})
+	// 扫描段文件，最后一个段文件的不完整记录（写入时掉电）将被截断
+	for i, seg := range q.segments {
+		if err := scanSegment(seg, i == len(q.segments)-1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 扫描段文件
+func scanSegment(seg *segment, truncate bool) error {
+	file, err := os.OpenFile(seg.path, os.O_RDWR, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	var offset int64
+	for {
+		ts, size, err := readRecordHeader(file, offset)
+		if err == nil {
+			// 校验数据
+			_, err = readRecordData(file, offset, size)
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if !truncate {
+				return fmt.Errorf("segment %s is corrupted at offset %d: %w", seg.path, offset, err)
+			}
+			break
+		}
+		if seg.count == 0 {
+			seg.firstTime = ts
+		}
+		seg.lastTime = ts
+		seg.count++
+		offset += recordHeaderSize + int64(size)
+	}
+	seg.size = offset
+	// 截断不完整的记录
+	if truncate {
+		return file.Truncate(offset)
+	}
+	return nil
+}
+
+// 读取记录头
+func readRecordHeader(file *os.File, offset int64) (time.Time, uint32, error) {
+	var head [recordHeaderSize]byte
+	n, err := file.ReadAt(head[:], offset)
+	if n == 0 && errors.Is(err, io.EOF) {
+		return time.Time{}, 0, io.EOF
+	}
+	if n < recordHeaderSize {
+		return time.Time{}, 0, io.ErrUnexpectedEOF
+	}
+	size := binary.BigEndian.Uint32(head[0:4])
+	ts := time.Unix(0, int64(binary.BigEndian.Uint64(head[8:16])))
+	return ts, size, nil
+}
+
+// 读取并校验记录数据
+func readRecordData(file *os.File, offset int64, size uint32) ([]byte, error) {
+	var head [8]byte
+	if _, err := file.ReadAt(head[:], offset); err != nil {
+		return nil, err
+	}
+	data := make([]byte, size)
+	if _, err := file.ReadAt(data, offset+recordHeaderSize); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	if crc32.ChecksumIEEE(data) != binary.BigEndian.Uint32(head[4:8]) {
+		return nil, errors.New("checksum mismatch")
+	}
+	return data, nil
+}
+
+// Append 写入消息
+//
+//	@param data: 消息数据
+//	@return 消息序号
+//	@return 异常信息（达到最大容量时返回ErrQueueFull）
+func (q *Queue) Append(data []byte) (uint64, error) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	// 检查状态
+	if q.closed {
+		return 0, ErrQueueClosed
+	}
+	recordSize := int64(recordHeaderSize + len(data))
+	if recordSize > q.opts.SegmentSize {
+		return 0, ErrRecordTooLarge
+	}
+	now := time.Now()
+	// 清理已确认或超龄的段文件
+	q.cleanup(now)
+	// 检查容量（写入中的段文件已全部确认时删除，避免已确认的数据占用容量）
+	if q.opts.MaxSize > 0 && q.totalSize()+recordSize > q.opts.MaxSize {
+		if n := len(q.segments); n > 0 && q.segments[n-1].count > 0 && q.segments[n-1].lastSeq() <= q.ackSeq {
+			q.removeSegment(n - 1)
+		}
+		if q.totalSize()+recordSize > q.opts.MaxSize {
+			return 0, ErrQueueFull
+		}
+	}
+	// 获取写入的段文件（当前段文件写满时新建）
+	seg, err := q.writableSegment(recordSize)
+	if err != nil {
+		return 0, err
+	}
+	// 构建记录
+	record := make([]byte, recordSize)
+	binary.BigEndian.PutUint32(record[0:4], uint32(len(data)))
+	binary.BigEndian.PutUint32(record[4:8], crc32.ChecksumIEEE(data))
+	binary.BigEndian.PutUint64(record[8:16], uint64(now.UnixNano()))
+	copy(record[recordHeaderSize:], data)
+	// 写入记录
+	if _, err := q.writer.Write(record); err != nil {
+		// 写入失败时截断到写入前的位置，避免残留不完整记录
+		q.writer.Truncate(seg.size)
+		q.writer.Seek(seg.size, io.SeekStart)
+		return 0, err
+	}
+	if !q.opts.NoSync {
+		if err := q.writer.Sync(); err != nil {
+			return 0, err
+		}
+	}
+	// 更新状态
+	seq := q.nextSeq
+	if seg.count == 0 {
+		seg.firstTime = now
+	}
+	seg.lastTime = now
+	seg.count++
+	seg.size += recordSize
+	q.nextSeq++
+	q.appended++
+	// 通知等待中的读取者
+	close(q.notify)
+	q.notify = make(chan struct{})
+	// OK
+	return seq, nil
+}
+
+// 获取可写入的段文件
+func (q *Queue) writableSegment(recordSize int64) (*segment, error) {
+	// 当前段文件仍可写入
+	if n := len(q.segments); n > 0 {
+		last := q.segments[n-1]
+		if last.firstSeq+last.count == q.nextSeq && last.size+recordSize <= q.opts.SegmentSize {
+			if q.writer == nil {
+				file, err := os.OpenFile(last.path, os.O_WRONLY|os.O_APPEND, 0o644)
+				if err != nil {
+					return nil, err
+				}
+				q.writer = file
+			}
+			return last, nil
+		}
+	}
+	// 关闭当前段文件
+	if q.writer != nil {
+		q.writer.Close()
+		q.writer = nil
+	}
+	// 新建段文件
+	seg := &segment{
+		firstSeq: q.nextSeq,
+		path:     filepath.Join(q.opts.Dir, fmt.Sprintf("%020d%s", q.nextSeq, segmentExt)),
+	}
+	file, err := os.OpenFile(seg.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	q.writer = file
+	q.segments = append(q.segments, seg)
+	return seg, nil
+}
+
+// 段文件总字节数
+func (q *Queue) totalSize() int64 {
+	var total int64
+	for _, seg := range q.segments {
+		total += seg.size
+	}
+	return total
+}
+
+// 清理已确认或超龄的段文件（调用前需持有锁）
+func (q *Queue) cleanup(now time.Time) {
+	checkpointChanged := false
+	for len(q.segments) > 0 {
+		seg := q.segments[0]
+		isTail := len(q.segments) == 1
+		// 空段文件（非写入中）直接删除；写入中的段文件全部确认后保留，避免频繁新建段文件
+		empty := seg.count == 0 && !isTail
+		acked := seg.count > 0 && seg.lastSeq() <= q.ackSeq && !isTail
+		expired := q.opts.MaxAge > 0 && seg.count > 0 && now.Sub(seg.lastTime) > q.opts.MaxAge
+		if !empty && !acked && !expired {
+			break
+		}
+		// 超龄的段文件即使未确认也需要删除
+		if expired && seg.lastSeq() > q.ackSeq {
+			q.dropped += seg.lastSeq() - max(q.ackSeq, seg.firstSeq-1)
+			q.ackSeq = seg.lastSeq()
+			checkpointChanged = true
+		}
+		// 删除段文件
+		q.removeSegment(0)
+	}
+	// 读取位置不能落后于确认位置
+	if q.readSeq <= q.ackSeq {
+		q.readSeq = q.ackSeq + 1
+	}
+	if checkpointChanged {
+		q.saveCheckpoint()
+	}
+}
+
+// 删除段文件（调用前需持有锁）
+func (q *Queue) removeSegment(index int) {
+	seg := q.segments[index]
+	if index == len(q.segments)-1 && q.writer != nil {
+		q.writer.Close()
+		q.writer = nil
+	}
+	if q.reader != nil && q.readerSeg == seg.firstSeq {
+		q.reader.Close()
+		q.reader = nil
+	}
+	os.Remove(seg.path)
+	q.segments = append(q.segments[:index], q.segments[index+1:]...)
+}
+
+// Read 读取下一条消息（无消息时阻塞等待）
+//
+// 读取不会确认消息，处理完成后请调用Ack确认，未确认的消息在队列重新打开后会再次投递。
+// 由于确认为累计确认，请按读取顺序确认消息。
+//
+//	@param ctx: 上下文
+//	@return 消息
+//	@return 异常信息
+func (q *Queue) Read(ctx context.Context) (*Message, error) {
+	for {
+		q.mtx.Lock()
+		// 检查状态
+		if q.closed {
+			q.mtx.Unlock()
+			return nil, ErrQueueClosed
+		}
+		// 清理超龄的段文件
+		q.cleanup(time.Now())
+		// 存在未读取的消息
+		if q.readSeq < q.nextSeq {
+			msg, err := q.readLocked()
+			q.mtx.Unlock()
+			return msg, err
+		}
+		notify := q.notify
+		q.mtx.Unlock()
+		// 等待新消息
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-notify:
+		}
+	}
+}
+
+// 读取下一条消息（调用前需持有锁）
+func (q *Queue) readLocked() (*Message, error) {
+	// 定位消息所在的段文件
+	var seg *segment
+	for _, item := range q.segments {
+		if item.firstSeq <= q.readSeq && q.readSeq <= item.lastSeq() {
+			seg = item
+			break
+		}
+	}
+	if seg == nil {
+		return nil, fmt.Errorf("message %d not found", q.readSeq)
+	}
+	// 切换读取的段文件
+	if q.reader == nil || q.readerSeg != seg.firstSeq || q.readerSeq > q.readSeq {
+		if q.reader != nil {
+			q.reader.Close()
+		}
+		file, err := os.Open(seg.path)
+		if err != nil {
+			q.reader = nil
+			return nil, err
+		}
+		q.reader = file
+		q.readerSeg = seg.firstSeq
+		q.readerSeq = seg.firstSeq
+		q.readerOffs = 0
+	}
+	// 跳过读取位置之前的记录
+	for q.readerSeq < q.readSeq {
+		_, size, err := readRecordHeader(q.reader, q.readerOffs)
+		if err != nil {
+			return nil, err
+		}
+		q.readerOffs += recordHeaderSize + int64(size)
+		q.readerSeq++
+	}
+	// 读取记录
+	ts, size, err := readRecordHeader(q.reader, q.readerOffs)
+	if err != nil {
+		return nil, err
+	}
+	data, err := readRecordData(q.reader, q.readerOffs, size)
+	if err != nil {
+		return nil, err
+	}
+	q.readerOffs += recordHeaderSize + int64(size)
+	q.readerSeq++
+	// 更新读取位置
+	msg := &Message{
+		Seq:       q.readSeq,
+		Timestamp: ts,
+		Data:      data,
+	}
+	q.readSeq++
+	// OK
+	return msg, nil
+}
+
+// Ack 确认消息（累计确认，即确认序号小于等于seq的全部消息）
+func (q *Queue) Ack(seq uint64) error {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	// 检查状态
+	if q.closed {
+		return ErrQueueClosed
+	}
+	if seq <= q.ackSeq {
+		return nil
+	}
+	if seq >= q.nextSeq {
+		return fmt.Errorf("message %d has not been written", seq)
+	}
+	// 更新确认位置
+	q.acked += seq - q.ackSeq
+	q.ackSeq = seq
+	if err := q.saveCheckpoint(); err != nil {
+		return err
+	}
+	// 清理已确认的段文件
+	q.cleanup(time.Now())
+	// OK
+	return nil
+}
+
+// Rewind 将读取位置回退到第一条未确认的消息（用于消费失败后重新投递）
+func (q *Queue) Rewind() {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	q.readSeq = q.ackSeq + 1
+}
+
+// Consume 持续消费消息（至少一次投递）
+//
+// 由于确认为累计确认，同一队列同时只应有一个消费者。
+//
+// 处理函数返回nil时确认消息；返回异常时按重试间隔重新投递同一条消息，直到处理成功或上下文结束。
+//
+//	@param ctx: 上下文
+//	@param retryInterval: 处理失败重试间隔
+//	@param handler: 消息处理函数
+//	@return 异常信息（上下文结束或队列关闭）
+func (q *Queue) Consume(ctx context.Context, retryInterval time.Duration, handler func(msg *Message) error) error {
+	for {
+		// 读取消息
+		msg, err := q.Read(ctx)
+		if err != nil {
+			return err
+		}
+		// 处理消息直到成功
+		for {
+			if err := handler(msg); err == nil {
+				break
+			}
+			select {
+			case <-ctx.Done():
+				q.Rewind()
+				return ctx.Err()
+			case <-time.After(retryInterval):
+			}
+		}
+		// 确认消息
+		if err := q.Ack(msg.Seq); err != nil {
+			return err
+		}
+	}
+}
+
+// Stats 获取队列统计信息
+func (q *Queue) Stats() Stats {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	stats := Stats{
+		Segments:     len(q.segments),
+		Bytes:        q.totalSize(),
+		Pending:      q.nextSeq - 1 - q.ackSeq,
+		Unread:       q.nextSeq - q.readSeq,
+		Appended:     q.appended,
+		Acked:        q.acked,
+		Dropped:      q.dropped,
+		NextSeq:      q.nextSeq,
+		CheckpointAt: q.ackSeq,
+	}
+	// 最早未确认消息的写入时间（按段文件粒度估算）
+	for _, seg := range q.segments {
+		if seg.count > 0 && seg.lastSeq() > q.ackSeq {
+			stats.OldestUnack = seg.firstTime
+			break
+		}
+	}
+	return stats
+}
+
+// Close 关闭队列
+func (q *Queue) Close() error {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	if q.closed {
+		return nil
+	}
+	q.closed = true
+	close(q.notify)
+	var errs []error
+	if q.writer != nil {
+		errs = append(errs, q.writer.Close())
+		q.writer = nil
+	}
+	if q.reader != nil {
+		errs = append(errs, q.reader.Close())
+		q.reader = nil
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,164 @@
+package enumlabel
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// ErrOutOfRange：枚举值超出取值范围
+var ErrOutOfRange = errors.New("enum value out of range")
+
+// Lang 标签语言
+type Lang int
+
+// 标签语言枚举
+const (
+	LangZh Lang = iota // 中文
+	LangEn             // 英文
+)
+
+// Integer 枚举底层类型约束
+type Integer interface {
+	~int | ~int64
+}
+
+// Item 枚举项
+type Item[T Integer] struct {
+	Code T      // 枚举值
+	Zh   string // 中文标签
+	En   string // 英文标签
+}
+
+// Table 枚举标签表
+type Table[T Integer] struct {
+	name  string        // 枚举名称（用于异常信息）
+	items map[T]Item[T] // 枚举项
+	codes []T           // 枚举值（升序）
+}
+
+// NewTable 创建枚举标签表
+//
+//	@param name: 枚举名称
+//	@param items: 枚举项
+//	@return 枚举标签表
+func NewTable[T Integer](name string, items ...Item[T]) *Table[T] {
+	t := &Table[T]{
+		name:  name,
+		items: make(map[T]Item[T], len(items)),
+		codes: make([]T, 0, len(items)),
+	}
+	for _, item := range items {
+		if _, ok := t.items[item.Code]; !ok {
+			t.codes = append(t.codes, item.Code)
+		}
+		t.items[item.Code] = item
+	}
+	sort.Slice(t.codes, func(i, j int) bool { return t.codes[i] < t.codes[j] })
+	return t
+}
+
+// Name 枚举名称
+func (t *Table[T]) Name() string {
+	return t.name
+}
+
+// Items 全部枚举项（按枚举值升序）
+func (t *Table[T]) Items() []Item[T] {
+	items := make([]Item[T], 0, len(t.codes))
+	for _, code := range t.codes {
+		items = append(items, t.items[code])
+	}
+	return items
+}
+
+// Valid 枚举值是否在取值范围内
+func (t *Table[T]) Valid(v T) bool {
+	_, ok := t.items[v]
+	return ok
+}
+
+// Validate 校验枚举值（超出取值范围时返回包装了ErrOutOfRange的异常）
+func (t *Table[T]) Validate(v T) error {
+	if t.Valid(v) {
+		return nil
+	}
+	return fmt.Errorf("%s %d: %w", t.name, int64(v), ErrOutOfRange)
+}
+
+// Label 获取枚举标签（超出取值范围时返回“未知(值)”）
+func (t *Table[T]) Label(v T, lang Lang) string {
+	item, ok := t.items[v]
+	switch {
+	case ok && lang == LangEn:
+		return item.En
+	case ok:
+		return item.Zh
+	case lang == LangEn:
+		return fmt.Sprintf("unknown(%d)", int64(v))
+	default:
+		return fmt.Sprintf("未知(%d)", int64(v))
+	}
+}
+
+// Value 带标签的枚举值
+type Value struct {
+	Code int64  `json:"code"` // 枚举值
+	Zh   string `json:"zh"`   // 中文标签
+	En   string `json:"en"`   // 英文标签
+}
+
+// Value 获取带标签的枚举值
+func (t *Table[T]) Value(v T) Value {
+	return Value{
+		Code: int64(v),
+		Zh:   t.Label(v, LangZh),
+		En:   t.Label(v, LangEn),
+	}
+}
+
+// Marshal 序列化枚举值为同时包含枚举值与中英文标签的JSON对象
+//
+//	如：{"code":1,"zh":"长头发","en":"long hair"}
+func (t *Table[T]) Marshal(v T) ([]byte, error) {
+	return json.Marshal(t.Value(v))
+}
+
+// Unmarshal 反序列化枚举值
+//
+// 兼容设备上报的数字、数字字符串，以及Marshal输出的JSON对象；超出取值范围的值会原样保留，可通过Valid校验
+func (t *Table[T]) Unmarshal(data []byte) (T, error) {
+	data = bytes.TrimSpace(data)
+	// null
+	if bytes.Equal(data, []byte("null")) {
+		return 0, nil
+	}
+	// JSON对象
+	if len(data) > 0 && data[0] == '{' {
+		var obj Value
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return 0, err
+		}
+		return T(obj.Code), nil
+	}
+	// 数字字符串
+	if len(data) > 0 && data[0] == '"' {
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return 0, err
+		}
+		if str == "" {
+			return 0, nil
+		}
+		data = []byte(str)
+	}
+	// 数字
+	code, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid value %s", t.name, string(data))
+	}
+	return T(code), nil
+}
@@ -25,6 +25,11 @@ func NewConnect(conn net.Conn) *Connect {
 	}
 }
 
+// RemoteAddr 获取远端地址（设备地址）
+func (ci *Connect) RemoteAddr() net.Addr {
+	return ci.conn.RemoteAddr()
+}
+
 // HttpClient 获取HTTP客户端
 func (ci *Connect) LockHttpClient() *HttpClient {
 	ci.mtx.Lock()
@@ -1,6 +1,7 @@
 package httpconn
 
 import (
+	"io"
 	"net"
 	"net/http"
 	"time"
@@ -56,11 +57,63 @@ func (c *HttpClient) SetTimeout(readTimeout, writeTimeout time.Duration) *HttpCl
 	return c
 }
 
+// GetTimeout 获取请求响应超时时间
+//
+//	@return 消息读取超时时间
+//	@return 消息发送超时时间
+func (c *HttpClient) GetTimeout() (time.Duration, time.Duration) {
+	return c.readTimeout, c.writeTimeout
+}
+
+// RefreshReadDeadline 刷新读超时截止时间
+//
+//	读取耗时较长的响应体（如录像下载）时，每次读取前调用以避免整体读取时间超过读超时时间
+func (c *HttpClient) RefreshReadDeadline() error {
+	return c.conn.SetReadDeadline(time.Now().Add(c.readTimeout))
+}
+
+// DeadlineReader 包装读取器，每次读取前刷新读超时截止时间
+//
+//	用于流式读取耗时较长的响应体（如录像下载、配置文件导出）
+func (c *HttpClient) DeadlineReader(r io.Reader) io.Reader {
+	return &deadlineReader{r: r, client: c}
+}
+
+// 每次读取前刷新读超时截止时间的读取器
+type deadlineReader struct {
+	r      io.Reader   // 底层读取器
+	client *HttpClient // HTTP客户端
+}
+
+// Read 读取数据
+func (r *deadlineReader) Read(p []byte) (int, error) {
+	if err := r.client.RefreshReadDeadline(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
+
+// RefreshWriteDeadline 刷新写超时截止时间
+//
+//	发送耗时较长的请求体（如升级包上传）时，每次读取请求体前调用以避免整体发送时间超过写超时时间
+func (c *HttpClient) RefreshWriteDeadline() error {
+	return c.conn.SetWriteDeadline(time.Now().Add(c.writeTimeout))
+}
+
 // IsSetAuthorization 是否已设置认证信息
 func (p *HttpClient) IsSetAuthorization() bool {
 	return p.auth != nil && p.auth.Type != HttpClientAuthTypeNone
 }
 
+// GetAuthorization 获取认证信息（副本，未设置时返回nil）
+func (p *HttpClient) GetAuthorization() *HttpClientAuth {
+	if p.auth == nil {
+		return nil
+	}
+	auth := *p.auth
+	return &auth
+}
+
 // BindAuthorizationChangeEvent 绑定认证信息修改事件
 func (p *HttpClient) BindAuthorizationChangeEvent(callback func(isClear bool)) {
 	p.authChangeEvent = callback
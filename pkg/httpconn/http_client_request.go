@@ -9,6 +9,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 // HttpClientRequest HTTP自定义客户端请求对象
@@ -240,3 +241,58 @@ func (r *HttpClientRequest) DecodeFormData(maxMemorySize int64) (*multipart.Form
 	// OK
 	return form, res, nil
 }
+
+// DecodeMultipart 执行请求并以流的方式逐个读取multipart响应的各个部分（请不要重复关闭Response.Body）
+//
+// 与DecodeFormData不同，各部分数据不会缓存在内存或落盘到临时文件，由回调函数直接读取；
+// 回调函数未读完的数据会被自动丢弃，返回异常时停止读取。
+//
+//	@param fn: 各部分的处理函数
+func (r *HttpClientRequest) DecodeMultipart(fn func(part *multipart.Part) error) (*http.Response, error) {
+	// 执行请求
+	res, err := r.Send()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		// 读完剩余数据，避免残留数据影响同一连接上的后续请求
+		io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+	}()
+	// 检查响应状态码
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+		if len(body) > 0 {
+			return res, errors.New(string(body))
+		}
+		return res, errors.New(res.Status)
+	}
+	// 解析boundary
+	mediatype, params, err := mime.ParseMediaType(res.Header.Get("Content-Type"))
+	if err != nil {
+		return res, err
+	}
+	if !strings.HasPrefix(mediatype, "multipart/") {
+		return res, errors.New("invalid Content-Type")
+	}
+	boundary, ok := params["boundary"]
+	if !ok {
+		return res, errors.New("invalid boundary") // 无法解析boundary
+	}
+	// 逐个读取
+	reader := multipart.NewReader(res.Body, boundary)
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return res, nil
+		}
+		if err != nil {
+			return res, err
+		}
+		err = fn(part)
+		part.Close()
+		if err != nil {
+			return res, err
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package httprecord
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// 归档文件魔数
+const archiveMagic = "SDCHTTPREC1\n"
+
+// 单条记录最大长度（防止损坏的归档文件导致超大内存分配）
+const maxRecordPartSize = 256 * 1024 * 1024
+
+// Record HTTP请求记录
+type Record struct {
+	Time       time.Time   `json:"time"`       // 接收时间
+	Method     string      `json:"method"`     // 请求方法
+	RequestURI string      `json:"requestURI"` // 请求URI（含Query参数）
+	Header     http.Header `json:"header"`     // 请求头
+	RemoteAddr string      `json:"remoteAddr"` // 来源地址
+	Body       []byte      `json:"-"`          // 原始请求体
+}
+
+// Writer 归档写入器
+//
+// 归档格式：gzip压缩流，以魔数开头，随后为若干条记录，
+// 每条记录为：4字节元信息长度 + 元信息JSON + 4字节请求体长度 + 原始请求体
+type Writer struct {
+	gz  *gzip.Writer  // gzip压缩写入器
+	buf *bufio.Writer // 缓冲写入器
+}
+
+// NewWriter 创建归档写入器
+func NewWriter(w io.Writer) (*Writer, error) {
+	gz := gzip.NewWriter(w)
+	buf := bufio.NewWriter(gz)
+	if _, err := buf.WriteString(archiveMagic); err != nil {
+		return nil, err
+	}
+	return &Writer{
+		gz:  gz,
+		buf: buf,
+	}, nil
+}
+
+// 写入带长度前缀的数据块
+func writePart(w io.Writer, data []byte) error {
+	var head [4]byte
+	binary.BigEndian.PutUint32(head[:], uint32(len(data)))
+	if _, err := w.Write(head[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// Write 写入一条记录
+func (w *Writer) Write(record *Record) error {
+	meta, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if err := writePart(w.buf, meta); err != nil {
+		return err
+	}
+	return writePart(w.buf, record.Body)
+}
+
+// Flush 将缓冲数据刷写到底层写入器（归档仍可继续写入）
+func (w *Writer) Flush() error {
+	if err := w.buf.Flush(); err != nil {
+		return err
+	}
+	return w.gz.Flush()
+}
+
+// Close 结束归档（不会关闭底层写入器）
+func (w *Writer) Close() error {
+	if err := w.buf.Flush(); err != nil {
+		return err
+	}
+	return w.gz.Close()
+}
+
+// Reader 归档读取器
+type Reader struct {
+	gz  *gzip.Reader  // gzip解压读取器
+	buf *bufio.Reader // 缓冲读取器
+}
+
+// NewReader 创建归档读取器
+func NewReader(r io.Reader) (*Reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	buf := bufio.NewReader(gz)
+	magic := make([]byte, len(archiveMagic))
+	if _, err := io.ReadFull(buf, magic); err != nil {
+		return nil, err
+	}
+	if string(magic) != archiveMagic {
+		return nil, errors.New("invalid http record archive")
+	}
+	return &Reader{
+		gz:  gz,
+		buf: buf,
+	}, nil
+}
+
+// 读取带长度前缀的数据块
+func readPart(r io.Reader) ([]byte, error) {
+	var head [4]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(head[:])
+	if size > maxRecordPartSize {
+		return nil, fmt.Errorf("record part too large: %d", size)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+// Next 读取下一条记录（读取完毕时返回io.EOF）
+func (r *Reader) Next() (*Record, error) {
+	meta, err := readPart(r.buf)
+	if err != nil {
+		// 未正常结束的归档（如录制进程异常退出）视为读取完毕
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+	var record Record
+	if err := json.Unmarshal(meta, &record); err != nil {
+		return nil, err
+	}
+	body, err := readPart(r.buf)
+	if err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+	record.Body = body
+	return &record, nil
+}
+
+// Close 关闭读取器（不会关闭底层读取器）
+func (r *Reader) Close() error {
+	return r.gz.Close()
+}
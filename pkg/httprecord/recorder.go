@@ -0,0 +1,91 @@
+package httprecord
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Recorder HTTP请求录制器
+//
+// 录制器读取完整的请求体写入归档后，将请求体还原到请求中，不影响后续处理
+type Recorder struct {
+	mtx    sync.Mutex // 互斥锁
+	writer *Writer    // 归档写入器
+	err    error      // 最近一次写入异常
+}
+
+// NewRecorder 创建HTTP请求录制器
+//
+//	@param w: 归档输出（如文件）
+//	@return HTTP请求录制器
+//	@return 异常信息
+func NewRecorder(w io.Writer) (*Recorder, error) {
+	writer, err := NewWriter(w)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{writer: writer}, nil
+}
+
+// Record 录制请求
+//
+// 可在任意HTTP框架的处理函数中调用（如gin的ctx.Request），调用后请求体仍可正常读取
+func (p *Recorder) Record(r *http.Request) error {
+	// 读取请求体
+	var body []byte
+	if r.Body != nil && r.Body != http.NoBody {
+		data, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return err
+		}
+		body = data
+		// 还原请求体
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	// 写入归档
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	err := p.writer.Write(&Record{
+		Time:       time.Now(),
+		Method:     r.Method,
+		RequestURI: r.URL.RequestURI(),
+		Header:     r.Header.Clone(),
+		RemoteAddr: r.RemoteAddr,
+		Body:       body,
+	})
+	if err == nil {
+		// 每条记录都刷写，保证进程异常退出时归档仍可读取
+		err = p.writer.Flush()
+	}
+	if err != nil {
+		p.err = err
+	}
+	return err
+}
+
+// Middleware 录制中间件（录制失败不影响请求处理，可通过Err获取最近一次异常）
+func (p *Recorder) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p.Record(r)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Err 获取最近一次写入异常
+func (p *Recorder) Err() error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.err
+}
+
+// Close 结束录制（不会关闭归档输出）
+func (p *Recorder) Close() error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.writer.Close()
+}
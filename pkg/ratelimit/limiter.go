@@ -0,0 +1,91 @@
+package ratelimit
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// 单次写入的最大分片大小（避免单次写入过大导致速率波动）
+const maxChunkSize = 32 * 1024
+
+// Limiter 带宽限制器（令牌桶，可在多个写入器之间共享）
+type Limiter struct {
+	mtx    sync.Mutex // 互斥锁
+	rate   float64    // 速率（字节/秒）
+	burst  float64    // 令牌桶容量（字节）
+	tokens float64    // 当前令牌数（可为负数，表示已预支）
+	last   time.Time  // 上次补充令牌时间
+}
+
+// NewLimiter 创建带宽限制器
+//
+//	@param bytesPerSecond: 速率（字节/秒，小于等于0时返回nil，表示不限速）
+//	@return 带宽限制器
+func NewLimiter(bytesPerSecond int64) *Limiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	return &Limiter{
+		rate:   float64(bytesPerSecond),
+		burst:  float64(bytesPerSecond),
+		tokens: float64(bytesPerSecond),
+		last:   time.Now(),
+	}
+}
+
+// WaitN 消耗n字节的令牌，令牌不足时阻塞等待（nil限制器不限速）
+func (l *Limiter) WaitN(n int) {
+	if l == nil || n <= 0 {
+		return
+	}
+	// 补充令牌并预支
+	l.mtx.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens -= float64(n)
+	wait := time.Duration(0)
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mtx.Unlock()
+	// 等待令牌补足
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+// 限速写入器
+type writer struct {
+	w       io.Writer // 底层写入器
+	limiter *Limiter  // 带宽限制器
+}
+
+// NewWriter 创建限速写入器（限制器为nil时直接返回底层写入器）
+func NewWriter(w io.Writer, limiter *Limiter) io.Writer {
+	if limiter == nil {
+		return w
+	}
+	return &writer{
+		w:       w,
+		limiter: limiter,
+	}
+}
+
+// Write 写入数据
+func (w *writer) Write(p []byte) (int, error) {
+	chunkSize := min(maxChunkSize, max(1, int(w.limiter.burst)))
+	written := 0
+	for len(p) > 0 {
+		chunk := p[:min(len(p), chunkSize)]
+		w.limiter.WaitN(len(chunk))
+		n, err := w.w.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		p = p[len(chunk):]
+	}
+	return written, nil
+}
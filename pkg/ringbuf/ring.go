@@ -0,0 +1,61 @@
+package ringbuf
+
+import "sync"
+
+// Ring 定长环形缓冲区（写满后覆盖最旧的数据，并发安全）
+type Ring[T any] struct {
+	mtx   sync.RWMutex // 读写锁
+	items []T          // 数据
+	head  int          // 最旧数据的位置
+	size  int          // 数据数量
+}
+
+// New 创建环形缓冲区
+//
+//	@param capacity: 容量（小于1时按1处理）
+//	@return 环形缓冲区
+func New[T any](capacity int) *Ring[T] {
+	return &Ring[T]{items: make([]T, max(capacity, 1))}
+}
+
+// Push 追加数据（写满时覆盖最旧的数据）
+func (r *Ring[T]) Push(item T) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.size < len(r.items) {
+		r.items[(r.head+r.size)%len(r.items)] = item
+		r.size++
+		return
+	}
+	r.items[r.head] = item
+	r.head = (r.head + 1) % len(r.items)
+}
+
+// Len 数据数量
+func (r *Ring[T]) Len() int {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return r.size
+}
+
+// Last 最新的数据
+func (r *Ring[T]) Last() (T, bool) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if r.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return r.items[(r.head+r.size-1)%len(r.items)], true
+}
+
+// Snapshot 按写入顺序（从旧到新）拷贝全部数据
+func (r *Ring[T]) Snapshot() []T {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	items := make([]T, r.size)
+	for i := range items {
+		items[i] = r.items[(r.head+i)%len(r.items)]
+	}
+	return items
+}
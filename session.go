@@ -14,17 +14,21 @@ import (
 	"github.com/kaicen-x/holosens-sdc-sdk/api/application/device"
 	"github.com/kaicen-x/holosens-sdc-sdk/api/application/metadata"
 	"github.com/kaicen-x/holosens-sdc-sdk/api/application/snapshot"
+	"github.com/kaicen-x/holosens-sdc-sdk/api/application/storage"
+	"github.com/kaicen-x/holosens-sdc-sdk/api/common"
 	"github.com/kaicen-x/holosens-sdc-sdk/api/details/itgt"
 	"github.com/kaicen-x/holosens-sdc-sdk/pkg/httpconn"
 )
 
 // Session 会话
 type Session struct {
-	httpConn        *httpconn.Connect // HTTP连接通道
-	deviceManager   *device.Manager   // 设备管理与维护管理器
-	metadataManager *metadata.Manager // 智能元数据对接管理器
-	snapshotManager *snapshot.Manager // 抓拍与图片下载管理器
-	itgtManager     *itgt.Manager     // 智能分析管理器
+	httpConn        *httpconn.Connect    // HTTP连接通道
+	deviceManager   *device.Manager      // 设备管理与维护管理器
+	metadataManager *metadata.Manager    // 智能元数据对接管理器
+	snapshotManager *snapshot.Manager    // 抓拍与图片下载管理器
+	storageManager  *storage.Manager     // 录像回放与下载管理器
+	itgtManager     *itgt.Manager        // 智能分析管理器
+	capabilities    *common.Capabilities // 设备能力
 }
 
 // 新建会话
@@ -47,14 +51,23 @@ func newSession(conn net.Conn) *Session {
 
 	// 构建HTTP连接通道实例
 	httpConn := httpconn.NewConnect(conn)
-	// OK
-	return &Session{
+	session := &Session{
 		httpConn:        httpConn,
 		deviceManager:   device.NewManager(httpConn),
 		metadataManager: metadata.NewManager(httpConn),
 		snapshotManager: snapshot.NewManager(httpConn),
+		storageManager:  storage.NewManager(httpConn),
 		itgtManager:     itgt.NewManager(httpConn),
+		capabilities:    common.NewCapabilities(),
 	}
+	// 各管理器共享会话的设备能力
+	session.deviceManager.SetCapabilities(session.capabilities)
+	session.metadataManager.SetCapabilities(session.capabilities)
+	session.snapshotManager.SetCapabilities(session.capabilities)
+	session.storageManager.SetCapabilities(session.capabilities)
+	session.itgtManager.SetCapabilities(session.capabilities)
+	// OK
+	return session
 }
 
 // Close 关闭会话（同时会关闭Socket连接）
@@ -95,6 +108,19 @@ func (p *Session) SetAuthorization(username, password string) {
 	client.SetDigestAuth(username, password)
 }
 
+// GetAuthorization 获取连接认证信息
+//
+//	@return 用户名
+//	@return 密码
+func (p *Session) GetAuthorization() (username, password string) {
+	client := p.GetHttp().LockHttpClient()
+	defer p.GetHttp().Unlock()
+	if auth := client.GetAuthorization(); auth != nil {
+		return auth.Username, auth.Password
+	}
+	return "", ""
+}
+
 // DeviceManager 获取设备管理与维护管理器
 func (p *Session) DeviceManager() *device.Manager {
 	return p.deviceManager
@@ -110,11 +136,23 @@ func (p *Session) SnapshotManager() *snapshot.Manager {
 	return p.snapshotManager
 }
 
+// StorageManager 获取录像回放与下载管理器
+func (p *Session) StorageManager() *storage.Manager {
+	return p.storageManager
+}
+
 // ItgtManager 获取智能分析管理器
 func (p *Session) ItgtManager() *itgt.Manager {
 	return p.itgtManager
 }
 
+// Capabilities 获取设备能力
+//
+// 新建会话时能力未知（不限制调用），可使用DiscoverCapabilities查询设备后确定。
+func (p *Session) Capabilities() *common.Capabilities {
+	return p.capabilities
+}
+
 // SessionWithServer 服务端会话
 type SessionWithServer struct {
 	*Session                                                 // 会话
@@ -34,6 +34,22 @@ type SessionCacheIface interface {
 	DeviceManager() *device.Manager
 }
 
+// SessionCacheEventType 会话缓存事件类型
+type SessionCacheEventType int
+
+// 会话缓存事件类型枚举
+const (
+	SessionCacheEventSet    SessionCacheEventType = iota // 会话加入（设备注册或重新注册）
+	SessionCacheEventRemove                              // 会话移除（心跳失败、未配置认证信息、被替换或主动移除）
+)
+
+// SessionCacheEvent 会话缓存事件
+type SessionCacheEvent struct {
+	Type     SessionCacheEventType // 事件类型
+	Key      string                // 唯一标识
+	Instance SessionCacheIface     // 会话实例（移除事件中的会话实例已被关闭）
+}
+
 // SessionCacheContext 会话缓存上下文
 type SessionCacheContext struct {
 	// 唯一标识
@@ -50,12 +66,102 @@ type SessionCacheContext struct {
 type SessionCache struct {
 	rwMtx    sync.RWMutex                    // 读写锁
 	cacheMap map[string]*SessionCacheContext // 缓存数据
+
+	listenerMtx sync.Mutex                            // 事件监听器互斥锁
+	listenerSeq int                                   // 事件监听器序号
+	listeners   map[int]func(event SessionCacheEvent) // 事件监听器
 }
 
 // NewConnectCache 创建会话缓存器
 func NewConnectCache() *SessionCache {
 	return &SessionCache{
-		cacheMap: make(map[string]*SessionCacheContext),
+		cacheMap:  make(map[string]*SessionCacheContext),
+		listeners: make(map[int]func(event SessionCacheEvent)),
+	}
+}
+
+// Listen 监听会话缓存事件
+//
+// 回调在触发事件的协程中同步执行（此时未持有缓存锁，可在回调中访问缓存），请勿在回调中执行耗时操作。
+//
+//	@param callback: 事件回调
+//	@return 取消监听函数
+func (c *SessionCache) Listen(callback func(event SessionCacheEvent)) (cancel func()) {
+	// 加锁
+	c.listenerMtx.Lock()
+	defer c.listenerMtx.Unlock()
+	// 注册监听器
+	c.listenerSeq++
+	id := c.listenerSeq
+	c.listeners[id] = callback
+	// 返回取消监听函数
+	return func() {
+		c.listenerMtx.Lock()
+		defer c.listenerMtx.Unlock()
+		delete(c.listeners, id)
+	}
+}
+
+// WatchSet 监听指定会话的下一次加入事件（用于等待设备重新注册）
+//
+// 请在执行可能导致设备重新注册的操作之前调用，以免错过事件。
+//
+//	@param key: 会话唯一标识
+//	@return 新会话通道（仅接收一次）
+//	@return 取消监听函数
+func (c *SessionCache) WatchSet(key string) (<-chan SessionCacheIface, func()) {
+	ch := make(chan SessionCacheIface, 1)
+	var once sync.Once
+	cancel := c.Listen(func(event SessionCacheEvent) {
+		if event.Type == SessionCacheEventSet && event.Key == key {
+			once.Do(func() { ch <- event.Instance })
+		}
+	})
+	return ch, cancel
+}
+
+// 触发会话缓存事件
+func (c *SessionCache) emit(events ...SessionCacheEvent) {
+	// 拷贝监听器，避免回调中注册或取消监听导致死锁
+	c.listenerMtx.Lock()
+	ids := make([]int, 0, len(c.listeners))
+	for id := range c.listeners {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	callbacks := make([]func(event SessionCacheEvent), 0, len(ids))
+	for _, id := range ids {
+		callbacks = append(callbacks, c.listeners[id])
+	}
+	c.listenerMtx.Unlock()
+	// 按注册顺序依次回调
+	for _, event := range events {
+		for _, callback := range callbacks {
+			callback(event)
+		}
+	}
+}
+
+// Range 遍历全部会话
+//
+//	@param fn: 遍历函数，返回false时结束遍历（遍历时未持有缓存锁，可在遍历函数中访问缓存）
+func (c *SessionCache) Range(fn func(key string, instance SessionCacheIface) bool) {
+	// 加读锁拷贝会话，避免遍历函数中访问缓存导致死锁
+	c.rwMtx.RLock()
+	keys := make([]string, 0, len(c.cacheMap))
+	instances := make(map[string]SessionCacheIface, len(c.cacheMap))
+	for key, cacheCtx := range c.cacheMap {
+		keys = append(keys, key)
+		instances[key] = cacheCtx.instance
+	}
+	c.rwMtx.RUnlock()
+	// 使用KEY进行排序
+	sort.Strings(keys)
+	// 遍历
+	for _, key := range keys {
+		if !fn(key, instances[key]) {
+			return
+		}
 	}
 }
 
@@ -99,6 +205,19 @@ func (c *SessionCache) GetListWithClient() []*SessionWithClient {
 	return list
 }
 
+// Get 获取会话（不区分服务端或客户端会话）
+func (c *SessionCache) Get(key string) (SessionCacheIface, error) {
+	// 加读锁
+	c.rwMtx.RLock()
+	defer c.rwMtx.RUnlock()
+	// 获取会话
+	if cacheCtx, ok := c.cacheMap[key]; ok {
+		return cacheCtx.instance, nil
+	}
+	// 会话不存在
+	return nil, ErrCacheKeyNotFound
+}
+
 // GetWithServer 获取服务端会话
 func (c *SessionCache) GetWithServer(key string) (*SessionWithServer, error) {
 	// 加读锁
@@ -133,10 +252,8 @@ func (c *SessionCache) GetWithClient(key string) (*SessionWithClient, error) {
 
 // KeepLive 心跳检测
 //
-//	心跳失败的设备将会移出缓存
+//	心跳失败的设备将会移出缓存（上下文终止时仅退出检测，会话的关闭与移除由调用方负责）
 func (c *SessionCache) keeplive(ctx context.Context, key string, instance SessionCacheIface) {
-	// 结束时移除会话
-	defer c.Remove(key)
 	// 心跳失败剩余缓冲次数
 	heartbeatMaxFailCount := 3
 	// 死循环开始
@@ -159,7 +276,9 @@ func (c *SessionCache) keeplive(ctx context.Context, key string, instance Sessio
 					// 继续检测
 					continue
 				}
-				// 跳出循环
+				// 缓冲次数耗尽，移除会话（仅移除自身，避免误删重新注册的新会话）
+				c.removeInstance(key, instance)
+				// 结束
 				return
 			}
 		}
@@ -168,13 +287,17 @@ func (c *SessionCache) keeplive(ctx context.Context, key string, instance Sessio
 
 // Set 添加会话
 func (c *SessionCache) Set(key string, instance SessionCacheIface) {
+	// 待触发的事件
+	events := make([]SessionCacheEvent, 0, 2)
+	// 解锁后触发事件
+	defer func() { c.emit(events...) }()
 	// 加写锁
 	c.rwMtx.Lock()
 	defer c.rwMtx.Unlock()
 	// 是否存在同一个设备
 	if cacheCtx, ok := c.cacheMap[key]; ok {
 		// 移除会话
-		c.remove(key, cacheCtx)
+		events = append(events, c.remove(key, cacheCtx))
 	}
 
 	// 创建会话上下文
@@ -213,18 +336,29 @@ func (c *SessionCache) Set(key string, instance SessionCacheIface) {
 	})
 	// 赋值会话
 	c.cacheMap[key] = cacheCtx
+	events = append(events, SessionCacheEvent{
+		Type:     SessionCacheEventSet,
+		Key:      key,
+		Instance: instance,
+	})
 	// 1分钟后检查设备是否仍然未配置认证信息
 	time.AfterFunc(time.Minute, func() {
 		// 是否未配置
 		if !instance.IsSetAuthorization() {
 			// 移除会话
-			c.Remove(key)
+			c.removeInstance(key, instance)
 		}
 	})
 }
 
 // 移除会话
-func (c *SessionCache) remove(key string, cacheCtx *SessionCacheContext) {
+func (c *SessionCache) remove(key string, cacheCtx *SessionCacheContext) SessionCacheEvent {
+	// 构建移除事件
+	event := SessionCacheEvent{
+		Type:     SessionCacheEventRemove,
+		Key:      key,
+		Instance: cacheCtx.instance,
+	}
 	// 心跳上下文取消器互斥锁
 	cacheCtx.keepliveCancelMtx.Lock()
 	defer cacheCtx.keepliveCancelMtx.Unlock()
@@ -239,16 +373,38 @@ func (c *SessionCache) remove(key string, cacheCtx *SessionCacheContext) {
 	cacheCtx.instance = nil
 	// 移除会话
 	delete(c.cacheMap, key)
+	// OK
+	return event
+}
+
+// 移除指定的会话实例（缓存中的会话已被替换时不做处理）
+func (c *SessionCache) removeInstance(key string, instance SessionCacheIface) {
+	// 待触发的事件
+	var events []SessionCacheEvent
+	// 解锁后触发事件
+	defer func() { c.emit(events...) }()
+	// 加写锁
+	c.rwMtx.Lock()
+	defer c.rwMtx.Unlock()
+	// 移除
+	if cacheCtx, ok := c.cacheMap[key]; ok && cacheCtx.instance == instance {
+		// 执行内部方法移除
+		events = append(events, c.remove(key, cacheCtx))
+	}
 }
 
 // Delete 移除会话
 func (c *SessionCache) Remove(key string) {
+	// 待触发的事件
+	var events []SessionCacheEvent
+	// 解锁后触发事件
+	defer func() { c.emit(events...) }()
 	// 加写锁
 	c.rwMtx.Lock()
 	defer c.rwMtx.Unlock()
 	// 移除
 	if cacheCtx, ok := c.cacheMap[key]; ok {
 		// 执行内部方法移除
-		c.remove(key, cacheCtx)
+		events = append(events, c.remove(key, cacheCtx))
 	}
 }
@@ -0,0 +1,745 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口抓拍图片批量导出
+ */
+package holosenssdcsdk
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/application/device"
+	"github.com/kaicen-x/holosens-sdc-sdk/api/application/snapshot"
+	"github.com/kaicen-x/holosens-sdc-sdk/pkg/ratelimit"
+)
+
+var (
+	// ErrSnapshotManagerNotFound：会话未提供抓拍管理器
+	ErrSnapshotManagerNotFound = errors.New("snapshot manager not found")
+	// ErrSnapshotExportIncomplete：导出未完成（可再次执行以继续导出）
+	ErrSnapshotExportIncomplete = errors.New("snapshot export incomplete")
+)
+
+// SnapshotManagerIface 提供抓拍管理器的会话接口
+type SnapshotManagerIface interface {
+	// 获取抓拍管理器
+	SnapshotManager() *snapshot.Manager
+}
+
+// SnapshotExportFormat 导出格式
+type SnapshotExportFormat int
+
+// 导出格式枚举
+const (
+	SnapshotExportDir SnapshotExportFormat = iota // 目录树
+	SnapshotExportZip                             // ZIP压缩包
+)
+
+// 导出过程文件
+const (
+	snapshotExportStateFile    = ".export-state.json" // 导出进度
+	snapshotExportJournalFile  = ".manifest.jsonl"    // 清单日志（逐条追加）
+	snapshotExportManifestJSON = "manifest.json"      // JSON清单
+	snapshotExportManifestCSV  = "manifest.csv"       // CSV清单
+)
+
+// SnapshotExportTarget 导出目标
+type SnapshotExportTarget struct {
+	Key   string   // 会话唯一标识
+	UUIDs []string // 通道UUID（为空时导出设备全部通道）
+}
+
+// SnapshotExportOptions 导出选项
+type SnapshotExportOptions struct {
+	// 输出路径（目录树格式为目录，ZIP格式为压缩包文件路径）
+	Output string
+	// 导出格式
+	Format SnapshotExportFormat
+	// 导出目标（为空时导出会话缓存器中的全部会话）
+	Targets []SnapshotExportTarget
+	// 开始时间（UTC时间戳，单位秒）
+	BeginTime int64
+	// 结束时间（UTC时间戳，单位秒）
+	EndTime int64
+	// 抓拍图类型（为空时不区分类型）
+	SnapshotTypes []snapshot.SnapshotType
+	// 其他过滤条件（UUID、时间范围与抓拍图类型以本选项为准）
+	Filter snapshot.QueryFilter
+	// 带宽限制（字节/秒，0表示不限速）
+	BytesPerSecond int64
+	// 进度回调（选填）
+	OnProgress func(progress SnapshotExportProgress)
+}
+
+// SnapshotExportEntry 导出清单条目
+type SnapshotExportEntry struct {
+	Device                string                                 `json:"device"`                // 会话唯一标识
+	UUID                  string                                 `json:"uuid"`                  // 通道UUID
+	SnapTime              int64                                  `json:"snapTime"`              // 抓拍时间（单位秒）
+	TimeType              int                                    `json:"timeType"`              // 抓拍时间类型（0-摄像机本地时间，1-UTC时间）
+	UTCTime               int64                                  `json:"utcTime,omitempty"`     // 按设备时区转换后的UTC抓拍时间（单位秒，仅摄像机本地时间有效，设备时区不可用时为0）
+	SnapshotType          int                                    `json:"snapshotType"`          // 抓拍图类型（-1表示导出时未区分类型）
+	LaneId                int                                    `json:"laneId"`                // 车道号
+	VehicleType           snapshot.SnapshotVehicleType           `json:"vehicleType"`           // 车辆类型
+	VehicleRegulationType snapshot.SnapshotVehicleRegulationType `json:"vehicleRegulationType"` // 违章类型
+	ContentId             string                                 `json:"contentId"`             // 抓拍图名称
+	ContentSize           int64                                  `json:"contentSize"`           // 抓拍图大小（单位：字节）
+	ContentType           string                                 `json:"contentType"`           // 抓拍图格式
+	File                  string                                 `json:"file"`                  // 导出文件相对路径（下载失败时为空）
+	Error                 string                                 `json:"error,omitempty"`       // 下载失败原因
+}
+
+// SnapshotExportProgress 导出进度
+type SnapshotExportProgress struct {
+	Device     string // 当前会话唯一标识
+	UUID       string // 当前通道UUID
+	Downloaded int    // 已下载数量
+	Skipped    int    // 已跳过数量（之前已导出）
+	Failed     int    // 下载失败数量
+	Bytes      int64  // 已下载字节数
+}
+
+// 导出进度（持久化）
+type snapshotExportState struct {
+	Cursors map[string]*snapshot.QueryCursorState `json:"cursors"`           // 遍历游标状态（键：会话/通道/类型）
+	Retries []SnapshotExportEntry                 `json:"retries,omitempty"` // 下载失败待重试的抓拍图（游标已越过）
+}
+
+// 抓拍图唯一标识（会话/通道/抓拍图名称）
+func snapshotExportID(entry *SnapshotExportEntry) string {
+	return entry.Device + "/" + entry.UUID + "/" + entry.ContentId
+}
+
+// SnapshotExportJob 抓拍图片批量导出任务
+//
+// 逐个会话、通道、抓拍图类型遍历抓拍图并下载，同时记录导出清单。
+// 导出进度持久化在输出目录中，中断（断线、进程退出）后使用相同选项再次执行即可继续导出。
+// ZIP格式先导出到“输出路径.parts”目录，全部完成后再打包为压缩包。
+type SnapshotExportJob struct {
+	cache    *SessionCache          // 会话缓存器
+	opts     SnapshotExportOptions  // 导出选项
+	dir      string                 // 导出目录
+	limiter  *ratelimit.Limiter     // 带宽限制器
+	state    snapshotExportState    // 导出进度
+	exported map[string]bool        // 已导出的抓拍图（键：会话/通道/抓拍图名称）
+	journal  *os.File               // 清单日志
+	progress SnapshotExportProgress // 导出进度
+}
+
+// NewSnapshotExportJob 创建抓拍图片批量导出任务
+//
+//	@param cache: 会话缓存器
+//	@param opts: 导出选项
+//	@return 抓拍图片批量导出任务
+func NewSnapshotExportJob(cache *SessionCache, opts SnapshotExportOptions) *SnapshotExportJob {
+	dir := opts.Output
+	if opts.Format == SnapshotExportZip {
+		dir = opts.Output + ".parts"
+	}
+	return &SnapshotExportJob{
+		cache:   cache,
+		opts:    opts,
+		dir:     dir,
+		limiter: ratelimit.NewLimiter(opts.BytesPerSecond),
+		state: snapshotExportState{
+			Cursors: make(map[string]*snapshot.QueryCursorState),
+		},
+		exported: make(map[string]bool),
+	}
+}
+
+// 加载导出进度与清单日志
+func (j *SnapshotExportJob) load() error {
+	// 导出进度
+	data, err := os.ReadFile(filepath.Join(j.dir, snapshotExportStateFile))
+	if err == nil {
+		if err := json.Unmarshal(data, &j.state); err != nil {
+			return err
+		}
+		if j.state.Cursors == nil {
+			j.state.Cursors = make(map[string]*snapshot.QueryCursorState)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	// 清单日志
+	entries, err := j.readJournal()
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.Error == "" {
+			j.exported[snapshotExportID(&entry)] = true
+		}
+	}
+	return nil
+}
+
+// 保存导出进度（先写临时文件再重命名，避免中断时损坏）
+func (j *SnapshotExportJob) save() error {
+	data, err := json.Marshal(&j.state)
+	if err != nil {
+		return err
+	}
+	name := filepath.Join(j.dir, snapshotExportStateFile)
+	if err := os.WriteFile(name+".tmp", data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(name+".tmp", name)
+}
+
+// 读取清单日志（忽略中断导致的不完整末行）
+func (j *SnapshotExportJob) readJournal() ([]SnapshotExportEntry, error) {
+	file, err := os.Open(filepath.Join(j.dir, snapshotExportJournalFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var entries []SnapshotExportEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry SnapshotExportEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// 追加清单日志
+func (j *SnapshotExportJob) appendJournal(entry *SnapshotExportEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := j.journal.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return j.journal.Sync()
+}
+
+// 清理路径中的非法字符
+func sanitizeExportPath(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|', ' ':
+			return '_'
+		}
+		return r
+	}, name)
+	if name == "" || name == "." || name == ".." {
+		return "_"
+	}
+	return name
+}
+
+// 根据图片格式获取扩展名
+func exportFileExt(contentType string) string {
+	mediatype, _, _ := mime.ParseMediaType(contentType)
+	switch mediatype {
+	case "image/jpeg", "image/jpg", "":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/bmp":
+		return ".bmp"
+	}
+	if exts, _ := mime.ExtensionsByType(mediatype); len(exts) > 0 {
+		return exts[0]
+	}
+	return ".bin"
+}
+
+// 通知进度
+func (j *SnapshotExportJob) notify() {
+	if j.opts.OnProgress != nil {
+		j.opts.OnProgress(j.progress)
+	}
+}
+
+// 加入待重试列表
+func (j *SnapshotExportJob) addRetry(entry SnapshotExportEntry) {
+	id := snapshotExportID(&entry)
+	for _, item := range j.state.Retries {
+		if snapshotExportID(&item) == id {
+			return
+		}
+	}
+	entry.Error, entry.File = "", ""
+	j.state.Retries = append(j.state.Retries, entry)
+}
+
+// 移出待重试列表
+func (j *SnapshotExportJob) removeRetry(id string) {
+	for i := range j.state.Retries {
+		if snapshotExportID(&j.state.Retries[i]) == id {
+			j.state.Retries = append(j.state.Retries[:i], j.state.Retries[i+1:]...)
+			return
+		}
+	}
+}
+
+// 重试之前下载失败的抓拍图
+func (j *SnapshotExportJob) retryFailed(ctx context.Context, instances map[string]SessionCacheIface) error {
+	retries := append([]SnapshotExportEntry(nil), j.state.Retries...)
+	for _, entry := range retries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		id := snapshotExportID(&entry)
+		if j.exported[id] {
+			j.removeRetry(id)
+			continue
+		}
+		// 会话不在线时保留，下次执行时重试
+		instance, ok := instances[entry.Device]
+		if !ok {
+			continue
+		}
+		provider, ok := instance.(SnapshotManagerIface)
+		if !ok {
+			continue
+		}
+		j.progress.Device, j.progress.UUID = entry.Device, entry.UUID
+		if err := j.download(provider.SnapshotManager(), &entry); err != nil {
+			entry.Error = err.Error()
+			j.progress.Failed++
+		} else {
+			j.exported[id] = true
+			j.removeRetry(id)
+			j.progress.Downloaded++
+			j.progress.Bytes += entry.ContentSize
+		}
+		if err := j.appendJournal(&entry); err != nil {
+			return err
+		}
+		if err := j.save(); err != nil {
+			return err
+		}
+		j.notify()
+	}
+	return nil
+}
+
+// 抓拍时间（返回是否为UTC时间，摄像机本地时间且设备时区不可用时按本地时间的字面值返回）
+func (e *SnapshotExportEntry) snapTime() (time.Time, bool) {
+	switch {
+	case e.TimeType != 0:
+		return time.Unix(e.SnapTime, 0).UTC(), true
+	case e.UTCTime != 0:
+		return time.Unix(e.UTCTime, 0).UTC(), true
+	}
+	return time.Unix(e.SnapTime, 0).UTC(), false
+}
+
+// 按设备时区将摄像机本地抓拍时间转换为UTC时间（设备时区不可用时返回0）
+func snapshotExportUTCTime(tz *device.TimeZoneInfo, local int64) int64 {
+	if tz == nil {
+		return 0
+	}
+	offset, err := tz.OffsetAt(time.Unix(local, 0).UTC())
+	if err != nil {
+		return 0
+	}
+	return local - int64(offset/time.Second)
+}
+
+// 下载单张抓拍图
+func (j *SnapshotExportJob) download(manager *snapshot.Manager, entry *SnapshotExportEntry) error {
+	snapTime, _ := entry.snapTime()
+	relDir := path.Join(sanitizeExportPath(entry.Device), sanitizeExportPath(entry.UUID), snapTime.Format("20060102"))
+	if err := os.MkdirAll(filepath.Join(j.dir, filepath.FromSlash(relDir)), 0755); err != nil {
+		return err
+	}
+	// 下载到临时文件
+	tmpName := filepath.Join(j.dir, filepath.FromSlash(relDir), sanitizeExportPath(entry.ContentId)+".part")
+	file, err := os.Create(tmpName)
+	if err != nil {
+		return err
+	}
+	reply, err := manager.ImageDownload(snapshot.ImageDownloadParams{
+		UUID:        entry.UUID,
+		ContentId:   entry.ContentId,
+		ContentSize: uint32(entry.ContentSize),
+	}, ratelimit.NewWriter(file, j.limiter))
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	// 重命名为最终文件
+	entry.ContentSize = reply.ContentSize
+	entry.ContentType = reply.ContentType
+	entry.File = path.Join(relDir, snapTime.Format("150405")+"_"+sanitizeExportPath(entry.ContentId)+exportFileExt(reply.ContentType))
+	return os.Rename(tmpName, filepath.Join(j.dir, filepath.FromSlash(entry.File)))
+}
+
+// 导出单个通道的单个抓拍图类型
+func (j *SnapshotExportJob) exportChannel(ctx context.Context, key string, manager *snapshot.Manager, tz *device.TimeZoneInfo, uuid string, snapshotType *snapshot.SnapshotType) error {
+	// 恢复或创建遍历游标
+	typeCode := -1
+	if snapshotType != nil {
+		typeCode = int(*snapshotType)
+	}
+	cursorKey := key + "/" + uuid + "/" + strconv.Itoa(typeCode)
+	var cursor *snapshot.QueryCursor
+	if state, ok := j.state.Cursors[cursorKey]; ok {
+		if state.Done {
+			return nil
+		}
+		cursor = manager.ResumeQueryCursor(*state)
+	} else {
+		filter := j.opts.Filter
+		filter.UUID = uuid
+		filter.BeginTime = j.opts.BeginTime
+		filter.EndTime = j.opts.EndTime
+		filter.SnapshotType = snapshotType
+		cursor = manager.NewQueryCursor(filter)
+	}
+
+	// 遍历抓拍图
+	j.progress.Device, j.progress.UUID = key, uuid
+	for cursor.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		record := cursor.Record()
+		if !j.exported[key+"/"+uuid+"/"+record.ContentId] {
+			entry := SnapshotExportEntry{
+				Device:                key,
+				UUID:                  uuid,
+				SnapTime:              record.SnapTime,
+				TimeType:              record.TimeType,
+				SnapshotType:          typeCode,
+				LaneId:                record.LaneId,
+				VehicleType:           record.VehicleType,
+				VehicleRegulationType: record.VehicleRegulationType,
+				ContentId:             record.ContentId,
+				ContentSize:           int64(record.ContentSize),
+			}
+			if record.TimeType == 0 {
+				entry.UTCTime = snapshotExportUTCTime(tz, record.SnapTime)
+			}
+			if err := j.download(manager, &entry); err != nil {
+				// 加入待重试列表，与游标位置一同保存
+				j.addRetry(entry)
+				entry.Error = err.Error()
+				j.progress.Failed++
+			} else {
+				j.exported[key+"/"+uuid+"/"+record.ContentId] = true
+				j.progress.Downloaded++
+				j.progress.Bytes += entry.ContentSize
+			}
+			if err := j.appendJournal(&entry); err != nil {
+				return err
+			}
+		} else {
+			j.progress.Skipped++
+		}
+		// 保存进度
+		state := cursor.State()
+		j.state.Cursors[cursorKey] = &state
+		if err := j.save(); err != nil {
+			return err
+		}
+		j.notify()
+	}
+	// 保存进度（断线时保存最后位置，下次执行时继续）
+	state := cursor.State()
+	j.state.Cursors[cursorKey] = &state
+	if err := j.save(); err != nil {
+		return err
+	}
+	return cursor.Err()
+}
+
+// 导出单个会话
+func (j *SnapshotExportJob) exportSession(ctx context.Context, target SnapshotExportTarget, instance SessionCacheIface) error {
+	// 获取抓拍管理器
+	provider, ok := instance.(SnapshotManagerIface)
+	if !ok {
+		return ErrSnapshotManagerNotFound
+	}
+	manager := provider.SnapshotManager()
+	// 设备时区（用于转换摄像机本地抓拍时间，查询失败时按本地时间的字面值导出）
+	tz, _ := instance.DeviceManager().TimeZoneQuery()
+	// 获取通道列表
+	uuids := target.UUIDs
+	if len(uuids) == 0 {
+		reply, err := instance.DeviceManager().ChannelInfoQuery()
+		if err != nil {
+			return err
+		}
+		for _, channel := range reply.CnsChnParam {
+			uuids = append(uuids, channel.Uuid)
+		}
+	}
+	// 抓拍图类型
+	types := make([]*snapshot.SnapshotType, 0, len(j.opts.SnapshotTypes))
+	for i := range j.opts.SnapshotTypes {
+		types = append(types, &j.opts.SnapshotTypes[i])
+	}
+	if len(types) == 0 {
+		types = append(types, nil)
+	}
+	// 逐个通道导出
+	var errs []error
+	for _, uuid := range uuids {
+		for _, snapshotType := range types {
+			if err := j.exportChannel(ctx, target.Key, manager, tz, uuid, snapshotType); err != nil {
+				if ctx.Err() != nil {
+					return err
+				}
+				errs = append(errs, fmt.Errorf("%s/%s: %w", target.Key, uuid, err))
+				// 会话异常时跳过该会话剩余通道
+				break
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// 写入导出清单
+func (j *SnapshotExportJob) writeManifest() error {
+	entries, err := j.readJournal()
+	if err != nil {
+		return err
+	}
+	// 同一张抓拍图仅保留最后一次结果（失败后重试成功的情况）
+	latest := make(map[string]int, len(entries))
+	list := make([]SnapshotExportEntry, 0, len(entries))
+	for _, entry := range entries {
+		id := entry.Device + "/" + entry.UUID + "/" + entry.ContentId
+		if idx, ok := latest[id]; ok {
+			if list[idx].Error != "" {
+				list[idx] = entry
+			}
+			continue
+		}
+		latest[id] = len(list)
+		list = append(list, entry)
+	}
+	sort.SliceStable(list, func(a, b int) bool {
+		if list[a].Device != list[b].Device {
+			return list[a].Device < list[b].Device
+		}
+		if list[a].UUID != list[b].UUID {
+			return list[a].UUID < list[b].UUID
+		}
+		return list[a].SnapTime < list[b].SnapTime
+	})
+
+	// JSON清单
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(j.dir, snapshotExportManifestJSON), data, 0644); err != nil {
+		return err
+	}
+
+	// CSV清单
+	file, err := os.Create(filepath.Join(j.dir, snapshotExportManifestCSV))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	writer := csv.NewWriter(file)
+	writer.Write([]string{"device", "uuid", "snap_time", "time_type", "snapshot_type", "lane_id", "vehicle_type", "vehicle_regulation_type", "content_id", "content_size", "content_type", "file", "error"})
+	for _, entry := range list {
+		// UTC时间带时区标识，无法转换的摄像机本地时间不带时区标识
+		snapTime, isUTC := entry.snapTime()
+		snapTimeText := snapTime.Format("2006-01-02T15:04:05")
+		if isUTC {
+			snapTimeText = snapTime.Format(time.RFC3339)
+		}
+		writer.Write([]string{
+			entry.Device,
+			entry.UUID,
+			snapTimeText,
+			strconv.Itoa(entry.TimeType),
+			strconv.Itoa(entry.SnapshotType),
+			strconv.Itoa(entry.LaneId),
+			strconv.Itoa(int(entry.VehicleType)),
+			strconv.Itoa(int(entry.VehicleRegulationType)),
+			entry.ContentId,
+			strconv.FormatInt(entry.ContentSize, 10),
+			entry.ContentType,
+			entry.File,
+			entry.Error,
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// 打包为ZIP压缩包（抓拍图已压缩，直接存储）
+func (j *SnapshotExportJob) pack() error {
+	tmpName := j.opts.Output + ".tmp"
+	file, err := os.Create(tmpName)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpName)
+	defer file.Close()
+	writer := zip.NewWriter(file)
+	err = filepath.WalkDir(j.dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(j.dir, name)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == snapshotExportStateFile || rel == snapshotExportJournalFile {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = rel
+		header.Method = zip.Store
+		if strings.HasPrefix(rel, "manifest.") {
+			header.Method = zip.Deflate
+		}
+		dst, err := writer.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		src, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		_, err = io.Copy(dst, src)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpName, j.opts.Output); err != nil {
+		return err
+	}
+	return os.RemoveAll(j.dir)
+}
+
+// Run 执行导出
+//
+// 全部导出完成后写入JSON与CSV清单（ZIP格式同时完成打包）；
+// 存在未完成的会话、通道或下载失败的抓拍图时，仍会写入已导出部分的清单并返回包装了ErrSnapshotExportIncomplete的异常，
+// 可使用相同选项再次执行以继续导出（下载失败的抓拍图在再次执行时优先重试）。
+//
+//	@param ctx: 上下文（取消时保存进度并返回）
+//	@return 异常信息
+func (j *SnapshotExportJob) Run(ctx context.Context) error {
+	// 准备导出目录
+	if err := os.MkdirAll(j.dir, 0755); err != nil {
+		return err
+	}
+	if err := j.load(); err != nil {
+		return err
+	}
+	journal, err := os.OpenFile(filepath.Join(j.dir, snapshotExportJournalFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	j.journal = journal
+	defer journal.Close()
+
+	// 导出目标
+	targets := j.opts.Targets
+	if len(targets) == 0 {
+		j.cache.Range(func(key string, instance SessionCacheIface) bool {
+			targets = append(targets, SnapshotExportTarget{Key: key})
+			return true
+		})
+	}
+	instances := make(map[string]SessionCacheIface)
+	j.cache.Range(func(key string, instance SessionCacheIface) bool {
+		instances[key] = instance
+		return true
+	})
+
+	// 重试之前下载失败的抓拍图
+	if err := j.retryFailed(ctx, instances); err != nil {
+		return err
+	}
+
+	// 逐个会话导出
+	var errs []error
+	for _, target := range targets {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		instance, ok := instances[target.Key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %w", target.Key, ErrCacheKeyNotFound))
+			continue
+		}
+		if err := j.exportSession(ctx, target, instance); err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+
+	// 写入清单
+	if err := j.writeManifest(); err != nil {
+		return err
+	}
+	if len(j.state.Retries) > 0 {
+		errs = append(errs, fmt.Errorf("%d snapshots failed to download", len(j.state.Retries)))
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrSnapshotExportIncomplete, errors.Join(errs...))
+	}
+
+	// 打包
+	if j.opts.Format == SnapshotExportZip {
+		journal.Close()
+		return j.pack()
+	}
+	return nil
+}
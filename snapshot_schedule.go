@@ -0,0 +1,322 @@
+/**
+ * @Author: Kaicen-X
+ * @Date: 2025/03/02 00:17
+ * @Description: 华为HoloSens SDC API北向接口定时抓拍调度
+ */
+package holosenssdcsdk
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"mime"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/kaicen-x/holosens-sdc-sdk/api/application/snapshot"
+	"github.com/kaicen-x/holosens-sdc-sdk/pkg/cron"
+)
+
+var (
+	// ErrSnapshotDeviceOffline：设备不在线（会话不存在）
+	ErrSnapshotDeviceOffline = errors.New("device offline")
+	// ErrSnapshotCaptureOverrun：上一次抓拍未及时完成，错过了本次抓拍时间
+	ErrSnapshotCaptureOverrun = errors.New("capture overrun")
+	// ErrSnapshotTaskExists：定时抓拍任务已存在
+	ErrSnapshotTaskExists = errors.New("snapshot task already exists")
+)
+
+// SnapshotScheduleTask 定时抓拍任务
+type SnapshotScheduleTask struct {
+	Name      string         // 任务名称（唯一）
+	Key       string         // 会话唯一标识
+	UUID      string         // 通道UUID
+	ChannelID int            // 通道ID（选填）
+	Spec      string         // 调度计划（cron表达式，如“*/5 * * * *”、“@every 30s”）
+	Jitter    time.Duration  // 随机延迟上限（选填，用于错开大量设备的同时抓拍）
+	Location  *time.Location // 调度计划时区（选填，默认本地时区）
+}
+
+// SnapshotCapture 抓拍信息
+type SnapshotCapture struct {
+	Task        string    // 任务名称
+	Key         string    // 会话唯一标识
+	UUID        string    // 通道UUID
+	ScheduledAt time.Time // 计划抓拍时间
+	CapturedAt  time.Time // 实际抓拍时间（抓拍失败时为零值）
+}
+
+// SnapshotCaptureMiss 错过的抓拍
+type SnapshotCaptureMiss struct {
+	SnapshotCapture       // 抓拍信息
+	Err             error // 错过原因（设备离线时为ErrSnapshotDeviceOffline）
+}
+
+// SnapshotImageSink 抓拍图片存储接口
+type SnapshotImageSink interface {
+	// 存储抓拍图片
+	Store(ctx context.Context, capture *SnapshotCapture, image *snapshot.SnapActionReply) error
+}
+
+// FileImageSink 本地文件系统抓拍图片存储
+//
+//	存储路径：Dir/会话唯一标识/通道UUID/YYYYMMDD/hhmmss.jpg
+type FileImageSink struct {
+	Dir string // 存储根目录
+}
+
+// Store 存储抓拍图片
+func (s *FileImageSink) Store(ctx context.Context, capture *SnapshotCapture, image *snapshot.SnapActionReply) error {
+	at := capture.ScheduledAt
+	dir := filepath.Join(s.Dir, sanitizeExportPath(capture.Key), sanitizeExportPath(capture.UUID), at.Format("20060102"))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	ext := ".jpg"
+	if mediatype, _, err := mime.ParseMediaType(image.ContentType); err == nil && mediatype != "image/jpeg" {
+		ext = exportFileExt(image.ContentType)
+	}
+	name := filepath.Join(dir, at.Format("150405")+ext)
+	if err := os.WriteFile(name+".tmp", image.Data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(name+".tmp", name)
+}
+
+// 定时抓拍任务运行上下文
+type snapshotScheduleWorker struct {
+	task     SnapshotScheduleTask // 定时抓拍任务
+	schedule cron.Schedule        // 调度计划
+	cancel   context.CancelFunc   // 任务取消器
+}
+
+// SnapshotScheduler 定时抓拍调度器
+//
+// 按调度计划对指定设备通道执行手动抓拍，并将抓拍图片交由存储接口保存：
+//
+//	1、每个任务独立调度，可为每个任务设置随机延迟以错开抓拍；
+//	2、全部任务共享并发上限，避免同时向大量设备发起抓拍；
+//	3、设备离线、抓拍失败或上一次抓拍未及时完成时，通过OnMissed回调报告错过的抓拍。
+type SnapshotScheduler struct {
+	cache       *SessionCache                  // 会话缓存器
+	sink        SnapshotImageSink              // 抓拍图片存储
+	concurrency chan struct{}                  // 并发控制
+	onCaptured  func(capture SnapshotCapture)  // 抓拍成功回调
+	onMissed    func(miss SnapshotCaptureMiss) // 错过抓拍回调
+	router      *ChannelRouter                 // 通道路由器（选填）
+
+	mtx     sync.Mutex                         // 互斥锁
+	ctx     context.Context                    // 调度器上下文
+	cancel  context.CancelFunc                 // 调度器取消器
+	workers map[string]*snapshotScheduleWorker // 定时抓拍任务
+	wg      sync.WaitGroup                     // 任务等待组
+}
+
+// NewSnapshotScheduler 创建定时抓拍调度器
+//
+//	@param cache: 会话缓存器
+//	@param sink: 抓拍图片存储（为nil时使用当前目录下的snapshots目录）
+//	@param concurrency: 最大并发抓拍数（小于等于0时默认为8）
+//	@return 定时抓拍调度器
+func NewSnapshotScheduler(cache *SessionCache, sink SnapshotImageSink, concurrency int) *SnapshotScheduler {
+	if sink == nil {
+		sink = &FileImageSink{Dir: "snapshots"}
+	}
+	if concurrency <= 0 {
+		concurrency = 8
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &SnapshotScheduler{
+		cache:       cache,
+		sink:        sink,
+		concurrency: make(chan struct{}, concurrency),
+		ctx:         ctx,
+		cancel:      cancel,
+		workers:     make(map[string]*snapshotScheduleWorker),
+	}
+}
+
+// OnCaptured 设置抓拍成功回调（请在添加任务之前调用）
+func (s *SnapshotScheduler) OnCaptured(callback func(capture SnapshotCapture)) {
+	s.onCaptured = callback
+}
+
+// OnMissed 设置错过抓拍回调（请在添加任务之前调用）
+func (s *SnapshotScheduler) OnMissed(callback func(miss SnapshotCaptureMiss)) {
+	s.onMissed = callback
+}
+
+// SetChannelRouter 设置通道路由器（请在添加任务之前调用）
+//
+//	设置后，未指定通道ID的任务按设备通道拓扑自动携带需要转发的通道ID
+func (s *SnapshotScheduler) SetChannelRouter(router *ChannelRouter) {
+	s.router = router
+}
+
+// AddTask 添加定时抓拍任务（添加后立即开始调度）
+//
+//	@param task: 定时抓拍任务
+//	@return 异常信息
+func (s *SnapshotScheduler) AddTask(task SnapshotScheduleTask) error {
+	schedule, err := cron.Parse(task.Spec)
+	if err != nil {
+		return err
+	}
+	if task.Location == nil {
+		task.Location = time.Local
+	}
+	// 加锁
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.ctx.Err() != nil {
+		return context.Canceled
+	}
+	if _, ok := s.workers[task.Name]; ok {
+		return ErrSnapshotTaskExists
+	}
+	// 启动任务
+	ctx, cancel := context.WithCancel(s.ctx)
+	worker := &snapshotScheduleWorker{
+		task:     task,
+		schedule: schedule,
+		cancel:   cancel,
+	}
+	s.workers[task.Name] = worker
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(ctx, worker)
+	}()
+	return nil
+}
+
+// RemoveTask 移除定时抓拍任务
+func (s *SnapshotScheduler) RemoveTask(name string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if worker, ok := s.workers[name]; ok {
+		worker.cancel()
+		delete(s.workers, name)
+	}
+}
+
+// Tasks 获取全部定时抓拍任务
+func (s *SnapshotScheduler) Tasks() []SnapshotScheduleTask {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	tasks := make([]SnapshotScheduleTask, 0, len(s.workers))
+	for _, worker := range s.workers {
+		tasks = append(tasks, worker.task)
+	}
+	return tasks
+}
+
+// Close 停止全部任务并等待正在进行的抓拍结束
+func (s *SnapshotScheduler) Close() {
+	s.mtx.Lock()
+	s.cancel()
+	s.workers = make(map[string]*snapshotScheduleWorker)
+	s.mtx.Unlock()
+	s.wg.Wait()
+}
+
+// 报告错过的抓拍
+func (s *SnapshotScheduler) missed(task *SnapshotScheduleTask, scheduledAt time.Time, err error) {
+	if s.onMissed == nil {
+		return
+	}
+	s.onMissed(SnapshotCaptureMiss{
+		SnapshotCapture: SnapshotCapture{
+			Task:        task.Name,
+			Key:         task.Key,
+			UUID:        task.UUID,
+			ScheduledAt: scheduledAt,
+		},
+		Err: err,
+	})
+}
+
+// 执行单次抓拍
+func (s *SnapshotScheduler) capture(ctx context.Context, task *SnapshotScheduleTask, scheduledAt time.Time) error {
+	// 获取会话
+	instance, err := s.cache.Get(task.Key)
+	if err != nil {
+		return ErrSnapshotDeviceOffline
+	}
+	provider, ok := instance.(SnapshotManagerIface)
+	if !ok {
+		return ErrSnapshotManagerNotFound
+	}
+	// 确定通道ID
+	channelID := task.ChannelID
+	if channelID == 0 && s.router != nil {
+		if route, err := s.router.Route(task.Key, task.UUID); err == nil {
+			channelID = route.ChannelID
+		}
+	}
+	// 抓拍
+	image, err := provider.SnapshotManager().SnapAction(snapshot.SnapActionParams{
+		UUID:      task.UUID,
+		ChannelID: channelID,
+	})
+	if err != nil {
+		return err
+	}
+	// 存储
+	capture := SnapshotCapture{
+		Task:        task.Name,
+		Key:         task.Key,
+		UUID:        task.UUID,
+		ScheduledAt: scheduledAt,
+		CapturedAt:  time.Now(),
+	}
+	if err := s.sink.Store(ctx, &capture, image); err != nil {
+		return err
+	}
+	if s.onCaptured != nil {
+		s.onCaptured(capture)
+	}
+	return nil
+}
+
+// 任务调度循环
+func (s *SnapshotScheduler) run(ctx context.Context, worker *snapshotScheduleWorker) {
+	task := &worker.task
+	next := worker.schedule.Next(time.Now().In(task.Location))
+	for !next.IsZero() {
+		// 等待到计划时间（叠加随机延迟）
+		delay := time.Until(next)
+		if task.Jitter > 0 {
+			delay += time.Duration(rand.Int63n(int64(task.Jitter)))
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// 获取并发许可
+		select {
+		case <-ctx.Done():
+			return
+		case s.concurrency <- struct{}{}:
+		}
+		err := s.capture(ctx, task, next)
+		<-s.concurrency
+		if err != nil {
+			s.missed(task, next, err)
+		}
+
+		// 计算下一次抓拍时间，报告抓拍耗时过长而错过的抓拍
+		now := time.Now().In(task.Location)
+		next = worker.schedule.Next(next)
+		for !next.IsZero() && next.Before(now) {
+			s.missed(task, next, ErrSnapshotCaptureOverrun)
+			next = worker.schedule.Next(next)
+		}
+	}
+}